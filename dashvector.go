@@ -3,7 +3,15 @@ package dashvector
 import "context"
 
 func NewClient(ctx context.Context, clientName ...string) Client {
-	return newCollections(client(ctx, clientName...))
+	return newCollections(newExecutor(client(ctx, clientName...), newClientConfig()))
+}
+
+func NewClientWithOptions(clusterEndpoint, apiKey string, configs ...ClientConfig) (Client, error) {
+	config := newClientConfig(configs...)
+	if err := validateClientConfig(clusterEndpoint, apiKey, config); err != nil {
+		return nil, err
+	}
+	return newCollections(newExecutor(newHttpClient(clusterEndpoint, apiKey, config), config)), nil
 }
 
 type Client interface {
@@ -13,6 +21,11 @@ type Client interface {
 	Stats(ctx context.Context, collectionName string) (CollectionStatsResponse, error)
 	Delete(ctx context.Context, collectionName string) (Response, error)
 	CreateServing(ctx context.Context, collectionName string, configs ...CollectionConfig) (Response, error)
+	DeleteServing(ctx context.Context, collectionName string) (Response, error)
+	EnsureCollection(ctx context.Context, collectionName string, configs ...CollectionConfig) (Response, error)
+	WaitForCollectionStatus(ctx context.Context, collectionName string, status Status) (CollectionDescResponse, error)
+	WaitUntilGone(ctx context.Context, collectionName string) error
+	WaitIndexed(ctx context.Context, collectionName string, threshold float64, progress ...func(CollectionStats)) (CollectionStatsResponse, error)
 	GetCollection(collectionName string) Collection
 }
 
@@ -38,6 +51,12 @@ type Collection interface {
 	Stats(ctx context.Context, partitionName string) (PartitionStatsResponse, error)
 	Delete(ctx context.Context, partitionName string) (Response, error)
 	CreateServing(ctx context.Context, partitionName string) (Response, error)
+	DeleteServing(ctx context.Context, partitionName string) (Response, error)
+	EnsurePartition(ctx context.Context, partitionName string) (Response, error)
+	WaitForPartitionStatus(ctx context.Context, partitionName string, status Status) (PartitionDescResponse, error)
+	WaitUntilGone(ctx context.Context, partitionName string) error
+	LoadSchema(ctx context.Context) (CollectionMeta, error)
+	ClearSchema()
 	GetPartition(partitionName ...string) Partition
 	Partition
 }
@@ -66,12 +85,16 @@ type Partition interface {
 	DropAll(ctx context.Context) (DocumentsWriteResponse, error)
 	Query(ctx context.Context, configs ...DocumentsQueryConfig) (DocumentsQueryResponse, error)
 	GroupQuery(ctx context.Context, field string, configs ...DocumentsGroupQueryConfig) (DocumentsGroupQueryResponse, error)
+	BulkWriter(configs ...BulkWriterConfig) BulkWriter
 }
 
 type DocumentsWriteResponse interface {
 	Response
 	GetOutput() []DocOpResult
 	GetUsage() ResponseUsage
+	Failed() []DocOpResult
+	Succeeded() []DocOpResult
+	Err() error
 }
 
 type DocumentsReadResponse interface {
@@ -89,4 +112,18 @@ type DocumentsQueryResponse interface {
 type DocumentsGroupQueryResponse interface {
 	Response
 	GetOutput() []Group
+	GetUsage() ResponseUsage
+}
+
+type BulkWriter interface {
+	Write(ctx context.Context, configs ...DocumentConfig) error
+	Flush(ctx context.Context) error
+	Close(ctx context.Context) error
+}
+
+type BulkWriteFailure struct {
+	Id      string
+	Code    int
+	Message string
+	Err     error
 }
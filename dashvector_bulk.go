@@ -0,0 +1,215 @@
+package dashvector
+
+import (
+	"context"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/samber/lo"
+	"sync"
+	"time"
+)
+
+var ErrBulkWriterClosed = gerror.NewCode(gcode.CodeInvalidOperation, "bulk writer is closed")
+
+func newBulkWriter(partition Partition, configs ...BulkWriterConfig) BulkWriter {
+	config := newBulkWriterConfig(configs...)
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &bulkWriter{
+		partition: partition,
+		config:    config,
+		ctx:       ctx,
+		cancel:    cancel,
+		docs:      make(chan *doc, config.MaxPending),
+		flushes:   make(chan *bulkFlush),
+		done:      make(chan struct{}),
+	}
+	go w.loop()
+	return w
+}
+
+type bulkWriter struct {
+	partition Partition
+	config    *bulkWriterConfig
+	ctx       context.Context
+	cancel    context.CancelFunc
+	docs      chan *doc
+	flushes   chan *bulkFlush
+	done      chan struct{}
+	mutex     sync.RWMutex
+	closed    bool
+	closeCtx  context.Context
+	closeErr  error
+	lastErr   error
+	buffer    []*doc
+}
+
+type bulkFlush struct {
+	ctx    context.Context
+	result chan error
+}
+
+func (w *bulkWriter) Write(ctx context.Context, configs ...DocumentConfig) error {
+	document := &doc{}
+	for _, cfg := range configs {
+		cfg(document)
+	}
+	if reason := validateUpsertDocument(document); reason != "" {
+		return gerror.NewCode(gcode.CodeInvalidParameter, reason)
+	}
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	if w.closed {
+		return ErrBulkWriterClosed
+	}
+	select {
+	case w.docs <- document:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *bulkWriter) Flush(ctx context.Context) error {
+	w.mutex.RLock()
+	if w.closed {
+		w.mutex.RUnlock()
+		return ErrBulkWriterClosed
+	}
+	flush := &bulkFlush{ctx: ctx, result: make(chan error, 1)}
+	select {
+	case w.flushes <- flush:
+		w.mutex.RUnlock()
+	case <-ctx.Done():
+		w.mutex.RUnlock()
+		return ctx.Err()
+	}
+	select {
+	case err := <-flush.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *bulkWriter) Close(ctx context.Context) error {
+	w.mutex.Lock()
+	if !w.closed {
+		w.closed = true
+		w.closeCtx = ctx
+		close(w.docs)
+	}
+	w.mutex.Unlock()
+	defer w.cancel()
+	select {
+	case <-w.done:
+		return w.closeErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *bulkWriter) loop() {
+	defer close(w.done)
+	var ticks <-chan time.Time
+	if w.config.FlushInterval > 0 {
+		ticker := time.NewTicker(w.config.FlushInterval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+	for {
+		select {
+		case document, ok := <-w.docs:
+			if !ok {
+				w.closeErr = w.takeErr(w.flush(w.closeCtx))
+				return
+			}
+			w.buffer = append(w.buffer, document)
+			if len(w.buffer) >= w.config.BatchSize {
+				w.keepErr(w.flush(w.ctx))
+			}
+		case flush := <-w.flushes:
+			w.drain()
+			flush.result <- w.takeErr(w.flush(flush.ctx))
+		case <-ticks:
+			w.keepErr(w.flush(w.ctx))
+		}
+	}
+}
+
+func (w *bulkWriter) drain() {
+	for {
+		select {
+		case document, ok := <-w.docs:
+			if !ok {
+				return
+			}
+			w.buffer = append(w.buffer, document)
+		default:
+			return
+		}
+	}
+}
+
+// keepErr keeps the error of a background flush for the next Flush or Close.
+func (w *bulkWriter) keepErr(err error) {
+	if err != nil {
+		w.lastErr = err
+	}
+}
+
+func (w *bulkWriter) takeErr(err error) error {
+	if err == nil {
+		err = w.lastErr
+	}
+	w.lastErr = nil
+	return err
+}
+
+func (w *bulkWriter) flush(ctx context.Context) error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+	buffer := w.buffer
+	w.buffer = nil
+	configs := make([]DocumentsConfig, 0, len(buffer))
+	for _, document := range buffer {
+		configs = append(configs, withDoc(document))
+	}
+	writeResponse, err := w.partition.Upsert(ctx, configs...)
+	var results map[string]DocOpResult
+	if writeResponse != nil {
+		results = lo.SliceToMap(writeResponse.GetOutput(), func(result DocOpResult) (string, DocOpResult) {
+			return result.GetId(), result
+		})
+	}
+	for _, document := range buffer {
+		result, ok := results[document.Id]
+		switch {
+		case ok && result.GetCode() != CodeSuccess:
+			w.fail(BulkWriteFailure{Id: result.GetId(), Code: result.GetCode(), Message: result.GetMessage()})
+		case !ok && (err != nil || writeResponse.GetCode() != CodeSuccess):
+			failure := BulkWriteFailure{Id: document.Id, Err: err}
+			if writeResponse != nil {
+				failure.Code = writeResponse.GetCode()
+				failure.Message = writeResponse.GetMessage()
+			}
+			w.fail(failure)
+		}
+	}
+	if err == nil && writeResponse.GetCode() != CodeSuccess {
+		err = newError(OperationDocsUpsert, 0, writeResponse)
+	}
+	return err
+}
+
+func (w *bulkWriter) fail(failure BulkWriteFailure) {
+	if w.config.FailureHandler != nil {
+		w.config.FailureHandler(failure)
+	}
+}
+
+func withDoc(document *doc) DocumentsConfig {
+	return func(request *documentsWriteRequest, validate func(*doc) string) {
+		request.appendDoc(document, validate)
+	}
+}
@@ -0,0 +1,124 @@
+package dashvector
+
+import (
+	"context"
+	"github.com/samber/lo"
+	"sync"
+)
+
+const (
+	defaultChunkSize        = 512
+	defaultChunkConcurrency = 1
+)
+
+var operationDocOps = map[Operation]DocOp{
+	OperationDocsInsert: DocOpInsert,
+	OperationDocsUpdate: DocOpUpdate,
+	OperationDocsUpsert: DocOpUpsert,
+	OperationDocsDrop:   DocOpDelete,
+}
+
+func writeInChunks(ctx context.Context, config *clientConfig, operation Operation, ids []string,
+	write func(ctx context.Context, from, to int) (DocumentsWriteResponse, error)) (DocumentsWriteResponse, error) {
+	total := len(ids)
+	size := config.ChunkSize
+	if size <= 0 || total <= size {
+		response, err := write(ctx, 0, total)
+		return mergeDocumentsWriteResponses(operation, [][]string{ids}, []DocumentsWriteResponse{response}), err
+	}
+	concurrency := config.ChunkConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultChunkConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	count := (total + size - 1) / size
+	chunkIds := make([][]string, count)
+	responses := make([]DocumentsWriteResponse, count)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	semaphore := make(chan struct{}, concurrency)
+	for i := 0; i < count; i++ {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() { <-semaphore; wg.Done() }()
+			from, to := i*size, (i+1)*size
+			if to > total {
+				to = total
+			}
+			chunkIds[i] = ids[from:to]
+			response, err := write(ctx, from, to)
+			responses[i] = response
+			if err != nil {
+				once.Do(func() { firstErr = err; cancel() })
+			}
+		}(i)
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return mergeDocumentsWriteResponses(operation, chunkIds, responses), firstErr
+}
+
+// mergeDocumentsWriteResponses keeps the code of the first failed chunk, and reports every doc
+// of a failed chunk without its own result as failed with that chunk's code and message.
+func mergeDocumentsWriteResponses(operation Operation, chunkIds [][]string, responses []DocumentsWriteResponse) DocumentsWriteResponse {
+	var merged *documentsWriteResponse
+	for i, response := range responses {
+		if response == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &documentsWriteResponse{Response: response, Output: make([]DocOpResult, 0)}
+		} else if merged.GetCode() == CodeSuccess && response.GetCode() != CodeSuccess {
+			merged.Response = response
+		}
+		merged.Output = append(merged.Output, response.GetOutput()...)
+		if response.GetCode() != CodeSuccess {
+			reported := lo.SliceToMap(response.GetOutput(), func(result DocOpResult) (string, bool) {
+				return result.GetId(), true
+			})
+			for _, id := range chunkIds[i] {
+				if !reported[id] {
+					merged.Output = append(merged.Output, &docOpResult{Id: id, Code: response.GetCode(),
+						Message: response.GetMessage(), DocOp: operationDocOps[operation]})
+				}
+			}
+		}
+		merged.Usage = sumResponseUsage(merged.Usage, response.GetUsage())
+	}
+	if merged == nil {
+		return nil
+	}
+	return merged
+}
+
+func sumResponseUsage(usages ...ResponseUsage) ResponseUsage {
+	var sum *responseUsage
+	for _, usage := range usages {
+		if usage == nil {
+			continue
+		}
+		if sum == nil {
+			sum = &responseUsage{}
+		}
+		sum.ReadUnits += usage.GetReadUnits()
+		sum.WriteUnits += usage.GetWriteUnits()
+	}
+	if sum == nil {
+		return nil
+	}
+	return sum
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/text/gstr"
+	"net/http"
 )
 
 const (
@@ -22,8 +24,10 @@ const (
 	configKeyForApiKey             = "dashvector.apiKey"
 	configKeyFmtForApiKey          = "dashvector.%s.apiKey"
 
-	baseUrlFmt      = "https://%s/v1"
+	baseUrlFmt      = "%s://%s/v1"
 	headerAuthToken = "dashvector-auth-token"
+
+	headerContentType = "Content-Type"
 )
 
 var (
@@ -45,23 +49,159 @@ func client(ctx context.Context, clientName ...string) *gclientx.Client {
 		if clusterEndpoint == "" || apiKey == "" {
 			panic(gerror.Newf("dashvector client config not found: %s", configKey))
 		}
-		return gx.Client().SetIntLog(logger).ContentJson().
-			Prefix(fmt.Sprintf(baseUrlFmt, clusterEndpoint)).
-			Header(headerAuthToken, apiKey)
+		return newHttpClient(clusterEndpoint, apiKey, newClientConfig())
 	}).(*gclientx.Client)
 }
 
+func newHttpClient(clusterEndpoint, apiKey string, config *clientConfig) *gclientx.Client {
+	httpClient := gx.Client().SetIntLog(logger)
+	if config.HttpClient != nil {
+		httpClient.Client.Client = *config.HttpClient
+	}
+	if config.Timeout > 0 {
+		httpClient.Client.SetTimeout(config.Timeout)
+	}
+	if config.UserAgent != "" {
+		httpClient.Client.SetAgent(config.UserAgent)
+	}
+	return httpClient.HeaderMap(config.Headers).ContentJson().
+		Prefix(fmt.Sprintf(baseUrlFmt, config.Scheme, clusterEndpoint)).
+		Header(headerAuthToken, apiKey)
+}
+
 func getConfigWithNamePattern(ctx context.Context, namePattern, name, defPattern string) (v string) {
 	return gvarx.DefaultIfEmpty(g.Cfg().MustGetWithEnv(ctx, fmt.Sprintf(namePattern, name)),
 		g.Cfg().MustGetWithEnv(ctx, defPattern).String()).String()
 }
 
-func decode[T any](parser func(json *gjson.Json) T,
-	requestBytes func(context.Context, string, ...any) ([]byte, error),
-	ctx context.Context, url string, data ...any) (T, error) {
-	bytes, err := requestBytes(ctx, url, data...)
+func newExecutor(client *gclientx.Client, config *clientConfig) *executor {
+	return &executor{Client: client, config: config}
+}
+
+type executor struct {
+	*gclientx.Client
+	config     *clientConfig
+	collection string
+	partition  string
+}
+
+// scope returns a copy of the executor reporting the given collection and partition to interceptors.
+func (e *executor) scope(collection, partition string) *executor {
+	scoped := *e
+	scoped.collection, scoped.partition = collection, partition
+	return &scoped
+}
+
+func decode[T Response](e *executor, operation Operation, parser func(json *gjson.Json) T,
+	ctx context.Context, method string, url string, data ...any) (T, error) {
+	response, err := invoke(e, ctx, e.newInvocation(operation, method, url, data...),
+		func(ctx context.Context, invocation *Invocation) (Response, error) {
+			return asResponse(execute(e, invocation, parser, ctx))
+		})
+	return assertResponse[T](operation, response, err)
+}
+
+func (e *executor) newInvocation(operation Operation, method string, url string, data ...any) *Invocation {
+	invocation := &Invocation{
+		Operation:  operation,
+		Collection: e.collection,
+		Partition:  e.partition,
+		Method:     method,
+		Url:        url,
+	}
+	if len(data) > 0 {
+		invocation.Request = data[0]
+	}
+	return invocation
+}
+
+// invoke runs one logical operation through tracing, metrics, the interceptors and the usage meter.
+func invoke(e *executor, ctx context.Context, invocation *Invocation, invoker Invoker) (Response, error) {
+	interceptors := e.config.Interceptors
+	if e.config.UsageMeter != nil {
+		interceptors = append(interceptors[:len(interceptors):len(interceptors)], e.config.UsageMeter)
+	}
+	invoker = chainInterceptors(interceptors, invoker)
+	if e.config.Metrics != nil {
+		invoker = chainInterceptors([]Interceptor{&metricsInterceptor{metrics: e.config.Metrics}}, invoker)
+	}
+	tracing := &tracingInterceptor{provider: e.config.TracerProvider}
+	return tracing.Intercept(ctx, invocation, invoker)
+}
+
+func asResponse[T Response](result T, err error) (Response, error) {
+	if any(result) == nil {
+		return nil, err
+	}
+	return result, err
+}
+
+func assertResponse[T Response](operation Operation, response Response, err error) (T, error) {
+	result, ok := response.(T)
+	if !ok && err == nil {
+		return result, gerror.Newf("dashvector %s: interceptor returned unexpected response %T", operation, response)
+	}
+	return result, err
+}
+
+func execute[T Response](e *executor, invocation *Invocation, parser func(json *gjson.Json) T,
+	ctx context.Context) (T, error) {
+	client := e.Client
+	if len(invocation.Header) > 0 {
+		client = client.HeaderMap(invocation.Header)
+	}
+	var data []any
+	if invocation.Request != nil {
+		data = append(data, invocation.Request)
+	}
+	operation := invocation.Operation
+	for attempt := 1; ; attempt++ {
+		result, statusCode, err := request(operation, parser, client, ctx, invocation.Method, invocation.Url, data...)
+		code := CodeSuccess
+		if err == nil {
+			code = result.GetCode()
+		}
+		if e.config.Retry.shouldRetry(ctx, operation, attempt, statusCode, code, err) &&
+			e.config.Retry.wait(ctx, attempt) == nil {
+			continue
+		}
+		if err == nil && e.config.CodeError && code != CodeSuccess {
+			return result, newError(operation, statusCode, result)
+		}
+		return result, err
+	}
+}
+
+func request[T Response](operation Operation, parser func(json *gjson.Json) T, client *gclientx.Client,
+	ctx context.Context, method string, url string, data ...any) (T, int, error) {
+	var zero T
+	response, err := client.DoRequest(ctx, method, url, data...)
 	if err != nil {
-		return any(nil).(T), err
+		return zero, 0, err
+	}
+	defer func() {
+		if err := response.Close(); err != nil {
+			logger.Errorf(ctx, `%+v`, err)
+		}
+	}()
+	statusCode, body := response.StatusCode, response.ReadAll()
+	json, ok := loadEnvelope(response.Header.Get(headerContentType), body)
+	if !ok || (statusCode >= http.StatusBadRequest && json.Get("code").Int() == CodeSuccess) {
+		return zero, statusCode, newResponseError(operation, response.Response, body)
+	}
+	return parser(json), statusCode, nil
+}
+
+func loadEnvelope(contentType string, body []byte) (*gjson.Json, bool) {
+	if contentType != "" && !gstr.ContainsI(contentType, "json") {
+		return nil, false
+	}
+	json, err := gjson.LoadJson(body)
+	if err != nil || json.IsNil() {
+		return nil, false
+	}
+	if _, ok := json.Interface().(map[string]any); !ok || !json.Contains("code") {
+		return nil, false
 	}
-	return parser(gjson.New(bytes)), nil
+	return json, true
 }
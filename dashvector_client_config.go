@@ -0,0 +1,229 @@
+package dashvector
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"time"
+)
+
+type ClientConfig func(*clientConfig)
+
+type RetryConfig func(*retryPolicy)
+
+type WaitConfig func(*waitPolicy)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func ClientWithHttpClient(httpClient *http.Client) ClientConfig {
+	return func(config *clientConfig) {
+		config.HttpClient = httpClient
+	}
+}
+
+func ClientWithTimeout(timeout time.Duration) ClientConfig {
+	return func(config *clientConfig) {
+		config.Timeout = timeout
+	}
+}
+
+func ClientWithScheme(scheme string) ClientConfig {
+	return func(config *clientConfig) {
+		config.Scheme = scheme
+	}
+}
+
+func ClientWithUserAgent(userAgent string) ClientConfig {
+	return func(config *clientConfig) {
+		config.UserAgent = userAgent
+	}
+}
+
+func ClientWithHeader(key, value string) ClientConfig {
+	return func(config *clientConfig) {
+		if config.Headers == nil {
+			config.Headers = make(map[string]string)
+		}
+		config.Headers[key] = value
+	}
+}
+
+func ClientWithCodeError(codeError bool) ClientConfig {
+	return func(config *clientConfig) {
+		config.CodeError = codeError
+	}
+}
+
+func ClientWithRetry(configs ...RetryConfig) ClientConfig {
+	return func(config *clientConfig) {
+		config.Retry = newRetryPolicy(configs...)
+	}
+}
+
+func ClientWithWait(configs ...WaitConfig) ClientConfig {
+	return func(config *clientConfig) {
+		config.Wait = newWaitPolicy(configs...)
+	}
+}
+
+func ClientWithChunkSize(chunkSize int) ClientConfig {
+	return func(config *clientConfig) {
+		config.ChunkSize = chunkSize
+	}
+}
+
+func ClientWithChunkConcurrency(chunkConcurrency int) ClientConfig {
+	return func(config *clientConfig) {
+		config.ChunkConcurrency = chunkConcurrency
+	}
+}
+
+func ClientWithFailedDocsRetry(failedDocsRetries int) ClientConfig {
+	return func(config *clientConfig) {
+		config.FailedDocsRetries = failedDocsRetries
+	}
+}
+
+func ClientWithStrictValidation(strictValidation bool) ClientConfig {
+	return func(config *clientConfig) {
+		config.StrictValidation = strictValidation
+	}
+}
+
+func ClientWithTracerProvider(provider trace.TracerProvider) ClientConfig {
+	return func(config *clientConfig) {
+		config.TracerProvider = provider
+	}
+}
+
+func ClientWithMetrics(metrics Metrics) ClientConfig {
+	return func(config *clientConfig) {
+		config.Metrics = metrics
+	}
+}
+
+func ClientWithUsageMeter(meter *UsageMeter) ClientConfig {
+	return func(config *clientConfig) {
+		config.UsageMeter = meter
+	}
+}
+
+func ClientWithInterceptors(interceptors ...Interceptor) ClientConfig {
+	return func(config *clientConfig) {
+		config.Interceptors = append(config.Interceptors, interceptors...)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func RetryWithMaxAttempts(maxAttempts int) RetryConfig {
+	return func(policy *retryPolicy) {
+		policy.MaxAttempts = maxAttempts
+	}
+}
+
+func RetryWithBaseDelay(baseDelay time.Duration) RetryConfig {
+	return func(policy *retryPolicy) {
+		policy.BaseDelay = baseDelay
+	}
+}
+
+func RetryWithMaxDelay(maxDelay time.Duration) RetryConfig {
+	return func(policy *retryPolicy) {
+		policy.MaxDelay = maxDelay
+	}
+}
+
+func RetryWithJitter(jitter float64) RetryConfig {
+	return func(policy *retryPolicy) {
+		policy.Jitter = jitter
+	}
+}
+
+func RetryWithCodes(codes ...int) RetryConfig {
+	return func(policy *retryPolicy) {
+		policy.Codes = make(map[int]bool, len(codes))
+		for _, code := range codes {
+			policy.Codes[code] = true
+		}
+	}
+}
+
+func RetryWithNonIdempotent(nonIdempotent bool) RetryConfig {
+	return func(policy *retryPolicy) {
+		policy.NonIdempotent = nonIdempotent
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func WaitWithInterval(interval time.Duration) WaitConfig {
+	return func(policy *waitPolicy) {
+		if interval <= 0 {
+			interval = defaultWaitInterval
+		}
+		policy.Interval = interval
+	}
+}
+
+func WaitWithMaxInterval(maxInterval time.Duration) WaitConfig {
+	return func(policy *waitPolicy) {
+		policy.MaxInterval = maxInterval
+	}
+}
+
+func WaitWithMultiplier(multiplier float64) WaitConfig {
+	return func(policy *waitPolicy) {
+		if !(multiplier >= 1) {
+			multiplier = 1
+		}
+		policy.Multiplier = multiplier
+	}
+}
+
+func WaitWithTimeout(timeout time.Duration) WaitConfig {
+	return func(policy *waitPolicy) {
+		policy.Timeout = timeout
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	schemeHttp  = "http"
+	schemeHttps = "https"
+)
+
+func newClientConfig(configs ...ClientConfig) *clientConfig {
+	config := &clientConfig{
+		Scheme:           schemeHttps,
+		Wait:             newWaitPolicy(),
+		ChunkSize:        defaultChunkSize,
+		ChunkConcurrency: defaultChunkConcurrency,
+	}
+	for _, cfg := range configs {
+		cfg(config)
+	}
+	return config
+}
+
+type clientConfig struct {
+	HttpClient *http.Client
+	Timeout    time.Duration
+	Scheme     string
+	UserAgent  string
+	Headers    map[string]string
+	CodeError  bool
+	Retry      *retryPolicy
+	Wait       *waitPolicy
+
+	ChunkSize        int
+	ChunkConcurrency int
+
+	FailedDocsRetries int
+	StrictValidation  bool
+
+	Interceptors   []Interceptor
+	TracerProvider trace.TracerProvider
+	Metrics        Metrics
+	UsageMeter     *UsageMeter
+}
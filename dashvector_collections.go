@@ -2,21 +2,22 @@ package dashvector
 
 import (
 	"context"
-	"github.com/CharLemAznable/gfx/net/gclientx"
+	"errors"
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/encoding/gjson"
-	"time"
+	"github.com/samber/lo"
+	"net/http"
 )
 
-func newCollections(client *gclientx.Client) Client {
+func newCollections(executor *executor) Client {
 	return &collections{
-		Client:         client,
+		executor:       executor,
 		collectionsMap: gmap.NewStrAnyMap(true),
 	}
 }
 
 type collections struct {
-	*gclientx.Client
+	*executor
 	collectionsMap *gmap.StrAnyMap
 }
 
@@ -25,32 +26,32 @@ func (c *collections) Create(ctx context.Context, collectionName string, configs
 		return nil, err
 	}
 	request := newCollectionCreateRequest(collectionName, configs...)
-	return decode(parseResponse, c.PostBytes, ctx, "/collections", request)
+	return decode(c.scope(collectionName, ""), OperationCollectionCreate, parseResponse, ctx, http.MethodPost, "/collections", request)
 }
 
 func (c *collections) Desc(ctx context.Context, collectionName string) (CollectionDescResponse, error) {
 	if err := validateCollectionName(ctx, collectionName); err != nil {
 		return nil, err
 	}
-	return decode(parseCollectionDescResponse, c.GetBytes, ctx, "/collections/"+collectionName)
+	return decode(c.scope(collectionName, ""), OperationCollectionDesc, parseCollectionDescResponse, ctx, http.MethodGet, "/collections/"+collectionName)
 }
 
 func (c *collections) List(ctx context.Context) (CollectionListResponse, error) {
-	return decode(parseCollectionListResponse, c.GetBytes, ctx, "/collections")
+	return decode(c.executor, OperationCollectionList, parseCollectionListResponse, ctx, http.MethodGet, "/collections")
 }
 
 func (c *collections) Stats(ctx context.Context, collectionName string) (CollectionStatsResponse, error) {
 	if err := validateCollectionName(ctx, collectionName); err != nil {
 		return nil, err
 	}
-	return decode(parseCollectionStatsResponse, c.GetBytes, ctx, "/collections/"+collectionName+"/stats")
+	return decode(c.scope(collectionName, ""), OperationCollectionStats, parseCollectionStatsResponse, ctx, http.MethodGet, "/collections/"+collectionName+"/stats")
 }
 
 func (c *collections) Delete(ctx context.Context, collectionName string) (Response, error) {
 	if err := validateCollectionName(ctx, collectionName); err != nil {
 		return nil, err
 	}
-	return decode(parseResponse, c.DeleteBytes, ctx, "/collections/"+collectionName)
+	return decode(c.scope(collectionName, ""), OperationCollectionDelete, parseResponse, ctx, http.MethodDelete, "/collections/"+collectionName)
 }
 
 func (c *collections) CreateServing(ctx context.Context, collectionName string, configs ...CollectionConfig) (Response, error) {
@@ -58,13 +59,100 @@ func (c *collections) CreateServing(ctx context.Context, collectionName string,
 	if err != nil || createResponse.GetCode() != 0 {
 		return createResponse, err
 	}
-	for {
-		time.Sleep(time.Millisecond * 10)
-		descResponse, err := c.Desc(ctx, collectionName)
-		if err != nil || descResponse.GetOutput().GetStatus() == StatusServing {
-			return descResponse, err
+	descResponse, err := c.WaitForCollectionStatus(ctx, collectionName, StatusServing)
+	if descResponse == nil {
+		return createResponse, err
+	}
+	return descResponse, err
+}
+
+func (c *collections) DeleteServing(ctx context.Context, collectionName string) (Response, error) {
+	deleteResponse, err := c.Delete(ctx, collectionName)
+	if err != nil || deleteResponse.GetCode() != 0 {
+		return deleteResponse, err
+	}
+	return deleteResponse, c.WaitUntilGone(ctx, collectionName)
+}
+
+func (c *collections) EnsureCollection(ctx context.Context, collectionName string, configs ...CollectionConfig) (Response, error) {
+	descResponse, err := c.Desc(ctx, collectionName)
+	if errors.Is(err, ErrCollectionNotFound) ||
+		(err == nil && descResponse.GetCode() == CodeInexistentCollection) {
+		return c.CreateServing(ctx, collectionName, configs...)
+	}
+	if err != nil || descResponse.GetCode() != 0 {
+		return descResponse, err
+	}
+	request := newCollectionCreateRequest(collectionName, configs...)
+	if diffs := diffCollectionSchema(request, descResponse.GetOutput()); len(diffs) > 0 {
+		return descResponse, &SchemaMismatchError{Collection: collectionName, Diffs: diffs}
+	}
+	if descResponse.GetOutput().GetStatus() == StatusServing {
+		return descResponse, nil
+	}
+	return c.WaitForCollectionStatus(ctx, collectionName, StatusServing)
+}
+
+func (c *collections) WaitForCollectionStatus(ctx context.Context, collectionName string, status Status) (CollectionDescResponse, error) {
+	if err := validateCollectionName(ctx, collectionName); err != nil {
+		return nil, err
+	}
+	var descResponse CollectionDescResponse
+	err := c.config.Wait.poll(ctx, func(ctx context.Context) (done bool, err error) {
+		descResponse, err = c.Desc(ctx, collectionName)
+		if err != nil {
+			return true, err
+		}
+		if descResponse.GetCode() != CodeSuccess {
+			return true, newError(OperationCollectionDesc, 0, descResponse)
+		}
+		switch current := descResponse.GetOutput().GetStatus(); current {
+		case status:
+			return true, nil
+		case StatusError:
+			return true, &FailedStatusError{Collection: collectionName, Status: current}
+		}
+		return false, nil
+	})
+	return descResponse, err
+}
+
+func (c *collections) WaitUntilGone(ctx context.Context, collectionName string) error {
+	if err := validateCollectionName(ctx, collectionName); err != nil {
+		return err
+	}
+	return c.config.Wait.poll(ctx, func(ctx context.Context) (bool, error) {
+		listResponse, err := c.List(ctx)
+		if err != nil {
+			return true, err
 		}
+		if listResponse.GetCode() != CodeSuccess {
+			return true, newError(OperationCollectionList, 0, listResponse)
+		}
+		return !lo.Contains(listResponse.GetOutput(), collectionName), nil
+	})
+}
+
+func (c *collections) WaitIndexed(ctx context.Context, collectionName string, threshold float64, progress ...func(CollectionStats)) (CollectionStatsResponse, error) {
+	if err := validateCollectionName(ctx, collectionName); err != nil {
+		return nil, err
 	}
+	var statsResponse CollectionStatsResponse
+	err := c.config.Wait.poll(ctx, func(ctx context.Context) (done bool, err error) {
+		statsResponse, err = c.Stats(ctx, collectionName)
+		if err != nil {
+			return true, err
+		}
+		if statsResponse.GetCode() != CodeSuccess {
+			return true, newError(OperationCollectionStats, 0, statsResponse)
+		}
+		stats := statsResponse.GetOutput()
+		for _, fn := range progress {
+			fn(stats)
+		}
+		return stats.GetIndexCompleteness() >= threshold, nil
+	})
+	return statsResponse, err
 }
 
 func (c *collections) GetCollection(collectionName string) Collection {
@@ -72,7 +160,7 @@ func (c *collections) GetCollection(collectionName string) Collection {
 		panic(err)
 	}
 	return c.collectionsMap.GetOrSetFuncLock(collectionName, func() any {
-		return newPartitions(c.Client, collectionName)
+		return newPartitions(c.executor, collectionName)
 	}).(Collection)
 }
 
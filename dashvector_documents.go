@@ -2,7 +2,6 @@ package dashvector
 
 import (
 	"context"
-	"github.com/CharLemAznable/gfx/net/gclientx"
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/encoding/gurl"
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -10,54 +9,59 @@ import (
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gvalid"
 	"github.com/samber/lo"
+	"net/http"
 )
 
-func newDocuments(client *gclientx.Client, collectionName string, partitionName string) Partition {
+func newDocuments(executor *executor, collectionName string, partitionName string, schema *collectionSchema) Partition {
 	return &documents{
-		Client:         client,
+		executor:       executor.scope(collectionName, partitionName),
 		collectionName: collectionName,
 		partitionName:  partitionName,
+		schema:         schema,
 	}
 }
 
 type documents struct {
-	*gclientx.Client
+	*executor
 	collectionName string
 	partitionName  string
+	schema         *collectionSchema
 }
 
 func (d *documents) Insert(ctx context.Context, configs ...DocumentsConfig) (DocumentsWriteResponse, error) {
-	request := newDocumentsWriteRequest(validInsertDocument, d.partitionName, configs...)
-	if len(request.Docs) == 0 {
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "docs is empty")
+	request, err := d.newWriteRequest(validateInsertDocument, configs...)
+	if err != nil {
+		return nil, err
 	}
-	return decode(parseDocumentsWriteResponse, d.PostBytes, ctx, "/collections/"+d.collectionName+"/docs", request)
+	return d.writeDocs(ctx, OperationDocsInsert, http.MethodPost, "/collections/"+d.collectionName+"/docs", request)
 }
 
 func (d *documents) Update(ctx context.Context, configs ...DocumentsConfig) (DocumentsWriteResponse, error) {
-	request := newDocumentsWriteRequest(validUpdateDocument, d.partitionName, configs...)
-	if len(request.Docs) == 0 {
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "docs is empty")
+	request, err := d.newWriteRequest(validateUpdateDocument, configs...)
+	if err != nil {
+		return nil, err
 	}
-	return decode(parseDocumentsWriteResponse, d.PutBytes, ctx, "/collections/"+d.collectionName+"/docs", request)
+	return d.writeDocs(ctx, OperationDocsUpdate, http.MethodPut, "/collections/"+d.collectionName+"/docs", request)
 }
 
 func (d *documents) Upsert(ctx context.Context, configs ...DocumentsConfig) (DocumentsWriteResponse, error) {
-	request := newDocumentsWriteRequest(func(d *doc) bool {
-		return validUpdateDocument(d) || validInsertDocument(d)
-	}, d.partitionName, configs...)
-	if len(request.Docs) == 0 {
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "docs is empty")
+	request, err := d.newWriteRequest(validateUpsertDocument, configs...)
+	if err != nil {
+		return nil, err
 	}
-	return decode(parseDocumentsWriteResponse, d.PostBytes, ctx, "/collections/"+d.collectionName+"/docs/upsert", request)
+	return d.writeDocs(ctx, OperationDocsUpsert, http.MethodPost, "/collections/"+d.collectionName+"/docs/upsert", request)
 }
 
 func (d *documents) Get(ctx context.Context, ids ...string) (DocumentsReadResponse, error) {
 	if len(ids) == 0 {
 		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "ids is empty")
 	}
-	return decode(parseDocumentsReadResponse, d.GetBytes, ctx, "/collections/"+d.collectionName+"/docs"+
+	readResponse, err := decode(d.executor, OperationDocsGet, parseDocumentsReadResponse, ctx, http.MethodGet, "/collections/"+d.collectionName+"/docs"+
 		"?ids="+gstr.Join(ids, ",")+"&partition="+gurl.Encode(d.partitionName))
+	if meta := d.schema.get(); meta != nil && readResponse != nil {
+		decodeDocsSchema(meta, lo.Values(readResponse.GetOutput())...)
+	}
+	return readResponse, err
 }
 
 func (d *documents) Drop(ctx context.Context, ids ...string) (DocumentsWriteResponse, error) {
@@ -65,17 +69,41 @@ func (d *documents) Drop(ctx context.Context, ids ...string) (DocumentsWriteResp
 		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "ids is empty")
 	}
 	request := newDocumentsDropRequest(d.partitionName, ids...)
-	return decode(parseDocumentsWriteResponse, d.DeleteBytes, ctx, "/collections/"+d.collectionName+"/docs", request)
+	invocation := d.newInvocation(OperationDocsDrop, http.MethodDelete, "/collections/"+d.collectionName+"/docs", request)
+	response, err := invoke(d.executor, ctx, invocation, func(ctx context.Context, invocation *Invocation) (Response, error) {
+		request, ok := invocation.Request.(*documentsDropRequest)
+		if !ok {
+			return nil, gerror.Newf("dashvector %s: unexpected request %T", invocation.Operation, invocation.Request)
+		}
+		return asResponse(writeInChunks(ctx, d.config, invocation.Operation, request.Ids, func(ctx context.Context, from, to int) (DocumentsWriteResponse, error) {
+			chunk := newDocumentsDropRequest(request.Partition, request.Ids[from:to]...)
+			return d.writeChunk(ctx, invocation, chunk, 0)
+		}))
+	})
+	return assertResponse[DocumentsWriteResponse](OperationDocsDrop, response, err)
 }
 
 func (d *documents) DropAll(ctx context.Context) (DocumentsWriteResponse, error) {
 	request := newDocumentsDropAllRequest(d.partitionName)
-	return decode(parseDocumentsWriteResponse, d.DeleteBytes, ctx, "/collections/"+d.collectionName+"/docs", request)
+	return decode(d.executor, OperationDocsDropAll, parseDocumentsWriteResponse, ctx, http.MethodDelete, "/collections/"+d.collectionName+"/docs", request)
 }
 
 func (d *documents) Query(ctx context.Context, configs ...DocumentsQueryConfig) (DocumentsQueryResponse, error) {
 	request := newDocumentsQueryRequest(d.partitionName, configs...)
-	return decode(parseDocumentsQueryResponse, d.PostBytes, ctx, "/collections/"+d.collectionName+"/query", request)
+	meta := d.schema.get()
+	if meta != nil {
+		if reason := validateQuerySchema(meta, request); reason != "" {
+			return nil, newSchemaValidationError(d.collectionName, reason)
+		}
+		if err := validateFilterSchema(meta, request.Filter); err != nil {
+			return nil, err
+		}
+	}
+	queryResponse, err := decode(d.executor, OperationDocsQuery, parseDocumentsQueryResponse, ctx, http.MethodPost, "/collections/"+d.collectionName+"/query", request)
+	if meta != nil && queryResponse != nil {
+		decodeDocsSchema(meta, queryResponse.GetOutput()...)
+	}
+	return queryResponse, err
 }
 
 func (d *documents) GroupQuery(ctx context.Context, field string, configs ...DocumentsGroupQueryConfig) (DocumentsGroupQueryResponse, error) {
@@ -84,7 +112,133 @@ func (d *documents) GroupQuery(ctx context.Context, field string, configs ...Doc
 		return nil, err
 	}
 	request := newDocumentsGroupQueryRequest(d.partitionName, field, configs...)
-	return decode(parseDocumentsGroupQueryResponse, d.PostBytes, ctx, "/collections/"+d.collectionName+"/query_group_by", request)
+	meta := d.schema.get()
+	if meta != nil {
+		if reason := validateGroupQuerySchema(meta, request); reason != "" {
+			return nil, newSchemaValidationError(d.collectionName, reason)
+		}
+		if err := validateFilterSchema(meta, request.Filter); err != nil {
+			return nil, err
+		}
+	}
+	groupQueryResponse, err := decode(d.executor, OperationDocsGroupQuery, parseDocumentsGroupQueryResponse, ctx, http.MethodPost, "/collections/"+d.collectionName+"/query_group_by", request)
+	if meta != nil && groupQueryResponse != nil {
+		for _, g := range groupQueryResponse.GetOutput() {
+			decodeDocsSchema(meta, g.GetDocs()...)
+		}
+	}
+	return groupQueryResponse, err
+}
+
+func (d *documents) BulkWriter(configs ...BulkWriterConfig) BulkWriter {
+	return newBulkWriter(d, configs...)
+}
+
+func (d *documents) newWriteRequest(validate func(*doc) string, configs ...DocumentsConfig) (*documentsWriteRequest, error) {
+	meta := d.schema.get()
+	if d.config.StrictValidation {
+		validate = strictDocumentValidator(validate)
+	}
+	if meta != nil {
+		validate = schemaDocumentValidator(meta, validate)
+	}
+	request := newDocumentsWriteRequest(validate, d.partitionName, configs...)
+	if (d.config.StrictValidation || meta != nil) && len(request.Violations) > 0 {
+		return nil, &ValidationError{Violations: request.Violations}
+	}
+	if len(request.Docs) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "docs is empty")
+	}
+	return request, nil
+}
+
+// writeDocs runs the whole chunked write with failed docs retries as one logical operation,
+// each request being traced as a child span.
+func (d *documents) writeDocs(ctx context.Context, operation Operation, method string, url string, request *documentsWriteRequest) (DocumentsWriteResponse, error) {
+	invocation := d.newInvocation(operation, method, url, request)
+	response, err := invoke(d.executor, ctx, invocation, func(ctx context.Context, invocation *Invocation) (Response, error) {
+		request, ok := invocation.Request.(*documentsWriteRequest)
+		if !ok {
+			return nil, gerror.Newf("dashvector %s: unexpected request %T", invocation.Operation, invocation.Request)
+		}
+		return asResponse(d.writeDocsInChunks(ctx, invocation, request))
+	})
+	return assertResponse[DocumentsWriteResponse](operation, response, err)
+}
+
+func (d *documents) writeDocsInChunks(ctx context.Context, invocation *Invocation, request *documentsWriteRequest) (DocumentsWriteResponse, error) {
+	retry := 0
+	write := func(request *documentsWriteRequest) (DocumentsWriteResponse, error) {
+		ids := lo.Map(request.Docs, func(document *doc, _ int) string { return document.Id })
+		return writeInChunks(ctx, d.config, invocation.Operation, ids, func(ctx context.Context, from, to int) (DocumentsWriteResponse, error) {
+			chunk := &documentsWriteRequest{Docs: request.Docs[from:to], Partition: request.Partition}
+			return d.writeChunk(ctx, invocation, chunk, retry)
+		})
+	}
+	writeResponse, err := write(request)
+	policy := d.config.Retry
+	if policy == nil {
+		policy = newRetryPolicy()
+	}
+	for attempt := 1; attempt <= d.config.FailedDocsRetries && err == nil; attempt++ {
+		retryRequest := failedDocumentsWriteRequest(request, writeResponse, policy.Codes)
+		if len(retryRequest.Docs) == 0 || policy.wait(ctx, attempt) != nil {
+			break
+		}
+		retry = attempt
+		retryResponse, retryErr := write(retryRequest)
+		if retryErr != nil {
+			break
+		}
+		writeResponse = replaceFailedResults(writeResponse, retryResponse)
+	}
+	return writeResponse, err
+}
+
+// writeChunk sends one request of a chunked write in a child span of the operation span.
+func (d *documents) writeChunk(ctx context.Context, invocation *Invocation, request any, retry int) (DocumentsWriteResponse, error) {
+	chunk := *invocation
+	chunk.Request = request
+	response, err := traceChunk(ctx, d.config.TracerProvider, &chunk, retry, func(ctx context.Context) (Response, error) {
+		return asResponse(execute(d.executor, &chunk, parseDocumentsWriteResponse, ctx))
+	})
+	return assertResponse[DocumentsWriteResponse](chunk.Operation, response, err)
+}
+
+// failedDocumentsWriteRequest collects the failed docs whose code is retryable,
+// permanent failures such as duplicate keys are not sent again.
+func failedDocumentsWriteRequest(request *documentsWriteRequest, writeResponse DocumentsWriteResponse, retryableCodes map[int]bool) *documentsWriteRequest {
+	failedIds := lo.SliceToMap(writeResponse.Failed(), func(result DocOpResult) (string, bool) {
+		return result.GetId(), retryableCodes[result.GetCode()]
+	})
+	return &documentsWriteRequest{
+		Docs: lo.Filter(request.Docs, func(document *doc, _ int) bool {
+			return document.Id != "" && failedIds[document.Id]
+		}),
+		Partition: request.Partition,
+	}
+}
+
+// replaceFailedResults takes the retried results over the failed ones, and the code of the retry
+// once a failed chunk has been fully written by it.
+func replaceFailedResults(writeResponse, retryResponse DocumentsWriteResponse) DocumentsWriteResponse {
+	retried := lo.SliceToMap(retryResponse.GetOutput(), func(result DocOpResult) (string, DocOpResult) {
+		return result.GetId(), result
+	})
+	replaced := &documentsWriteResponse{
+		Response: writeResponse,
+		Output: lo.Map(writeResponse.GetOutput(), func(result DocOpResult, _ int) DocOpResult {
+			if retry, ok := retried[result.GetId()]; ok && result.GetCode() != CodeSuccess {
+				return retry
+			}
+			return result
+		}),
+		Usage: sumResponseUsage(writeResponse.GetUsage(), retryResponse.GetUsage()),
+	}
+	if writeResponse.GetCode() != CodeSuccess && len(replaced.Failed()) == 0 {
+		replaced.Response = retryResponse
+	}
+	return replaced
 }
 
 func parseDocumentsWriteResponse(json *gjson.Json) DocumentsWriteResponse {
@@ -112,6 +266,32 @@ func (r *documentsWriteResponse) GetUsage() ResponseUsage {
 	return r.Usage
 }
 
+func (r *documentsWriteResponse) Failed() []DocOpResult {
+	return lo.Filter(r.Output, func(result DocOpResult, _ int) bool {
+		return result.GetCode() != CodeSuccess
+	})
+}
+
+func (r *documentsWriteResponse) Succeeded() []DocOpResult {
+	return lo.Filter(r.Output, func(result DocOpResult, _ int) bool {
+		return result.GetCode() == CodeSuccess
+	})
+}
+
+func (r *documentsWriteResponse) Err() error {
+	failed := r.Failed()
+	if r.GetCode() == CodeSuccess && len(failed) == 0 {
+		return nil
+	}
+	return &PartialWriteError{
+		Code:      r.GetCode(),
+		Message:   r.GetMessage(),
+		RequestId: r.GetRequestId(),
+		Total:     len(r.Output),
+		Failures:  failed,
+	}
+}
+
 func parseDocumentsReadResponse(json *gjson.Json) DocumentsReadResponse {
 	return &documentsReadResponse{
 		Response: parseResponse(json),
@@ -167,14 +347,20 @@ func parseDocumentsGroupQueryResponse(json *gjson.Json) DocumentsGroupQueryRespo
 		Response: parseResponse(json),
 		Output: lo.Map(json.Get("output").Array(),
 			func(item any, _ int) Group { return parseGroup(gjson.New(item)) }),
+		Usage: parseResponseUsage(json.GetJson("usage")),
 	}
 }
 
 type documentsGroupQueryResponse struct {
 	Response
 	Output []Group
+	Usage  ResponseUsage
 }
 
 func (r *documentsGroupQueryResponse) GetOutput() []Group {
 	return r.Output
 }
+
+func (r *documentsGroupQueryResponse) GetUsage() ResponseUsage {
+	return r.Usage
+}
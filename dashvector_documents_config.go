@@ -1,8 +1,12 @@
 package dashvector
 
-import "github.com/gogf/gf/v2/encoding/gjson"
+import (
+	"github.com/CharLemAznable/dashvector-sdk-go/filter"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"time"
+)
 
-type DocumentsConfig func(*documentsWriteRequest, func(*doc) bool)
+type DocumentsConfig func(*documentsWriteRequest, func(*doc) string)
 
 type DocumentConfig func(*doc)
 
@@ -12,18 +16,17 @@ type VectorQueryConfig func(*vectorQuery)
 
 type DocumentsGroupQueryConfig func(*documentsGroupQueryRequest)
 
+type BulkWriterConfig func(*bulkWriterConfig)
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func WithDocument(configs ...DocumentConfig) DocumentsConfig {
-	return func(request *documentsWriteRequest, valid func(*doc) bool) {
+	return func(request *documentsWriteRequest, validate func(*doc) string) {
 		document := &doc{}
 		for _, cfg := range configs {
 			cfg(document)
 		}
-		if !valid(document) {
-			return
-		}
-		request.Docs = append(request.Docs, document)
+		request.appendDoc(document, validate)
 	}
 }
 
@@ -115,6 +118,12 @@ func QueryWithFilter(filter string) DocumentsQueryConfig {
 	}
 }
 
+func QueryWithFilterExpr(expr filter.Expr) DocumentsQueryConfig {
+	return func(request *documentsQueryRequest) {
+		request.Filter = filterString(expr)
+	}
+}
+
 func QueryWithOutputFields(fields ...string) DocumentsQueryConfig {
 	return func(request *documentsQueryRequest) {
 		request.OutputFields = fields
@@ -215,6 +224,12 @@ func GroupQueryWithFilter(filter string) DocumentsGroupQueryConfig {
 	}
 }
 
+func GroupQueryWithFilterExpr(expr filter.Expr) DocumentsGroupQueryConfig {
+	return func(request *documentsGroupQueryRequest) {
+		request.Filter = filterString(expr)
+	}
+}
+
 func GroupQueryWithOutputFields(fields ...string) DocumentsGroupQueryConfig {
 	return func(request *documentsGroupQueryRequest) {
 		request.OutputFields = fields
@@ -229,17 +244,55 @@ func GroupQueryWithSchemaVector(vectorField string) DocumentsGroupQueryConfig {
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func newDocumentsWriteRequest(valid func(*doc) bool, partition string, configs ...DocumentsConfig) *documentsWriteRequest {
+func BulkWriterWithBatchSize(batchSize int) BulkWriterConfig {
+	return func(config *bulkWriterConfig) {
+		config.BatchSize = batchSize
+	}
+}
+
+func BulkWriterWithFlushInterval(flushInterval time.Duration) BulkWriterConfig {
+	return func(config *bulkWriterConfig) {
+		config.FlushInterval = flushInterval
+	}
+}
+
+func BulkWriterWithMaxPending(maxPending int) BulkWriterConfig {
+	return func(config *bulkWriterConfig) {
+		config.MaxPending = maxPending
+	}
+}
+
+func BulkWriterWithFailureHandler(handler func(BulkWriteFailure)) BulkWriterConfig {
+	return func(config *bulkWriterConfig) {
+		config.FailureHandler = handler
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newDocumentsWriteRequest(validate func(*doc) string, partition string, configs ...DocumentsConfig) *documentsWriteRequest {
 	request := &documentsWriteRequest{Docs: make([]*doc, 0), Partition: partition}
 	for _, cfg := range configs {
-		cfg(request, valid)
+		cfg(request, validate)
 	}
 	return request
 }
 
 type documentsWriteRequest struct {
-	Docs      []*doc `json:"docs"`
-	Partition string `json:"partition,omitempty"`
+	Docs       []*doc              `json:"docs"`
+	Partition  string              `json:"partition,omitempty"`
+	Violations []DocumentViolation `json:"-"`
+	count      int
+}
+
+func (r *documentsWriteRequest) appendDoc(document *doc, validate func(*doc) string) {
+	index := r.count
+	r.count++
+	if reason := validate(document); reason != "" {
+		r.Violations = append(r.Violations, DocumentViolation{Index: index, Id: document.Id, Reason: reason})
+		return
+	}
+	r.Docs = append(r.Docs, document)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -264,6 +317,13 @@ type documentsDropRequest struct {
 	DeleteAll bool     `json:"delete_all,omitempty"`
 }
 
+func filterString(expr filter.Expr) string {
+	if expr == nil {
+		return ""
+	}
+	return expr.String()
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func newDocumentsQueryRequest(partition string, configs ...DocumentsQueryConfig) *documentsQueryRequest {
@@ -365,3 +425,30 @@ type documentsGroupQueryRequest struct {
 	VectorField   string            `json:"vector_field,omitempty"`
 	Partition     string            `json:"partition,omitempty"`
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	defaultBulkWriterBatchSize     = 512
+	defaultBulkWriterFlushInterval = time.Second
+	defaultBulkWriterMaxPending    = 4096
+)
+
+func newBulkWriterConfig(configs ...BulkWriterConfig) *bulkWriterConfig {
+	config := &bulkWriterConfig{
+		BatchSize:     defaultBulkWriterBatchSize,
+		FlushInterval: defaultBulkWriterFlushInterval,
+		MaxPending:    defaultBulkWriterMaxPending,
+	}
+	for _, cfg := range configs {
+		cfg(config)
+	}
+	return config
+}
+
+type bulkWriterConfig struct {
+	BatchSize      int
+	FlushInterval  time.Duration
+	MaxPending     int
+	FailureHandler func(BulkWriteFailure)
+}
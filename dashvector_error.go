@@ -0,0 +1,184 @@
+package dashvector
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//goland:noinspection GoUnusedConst
+const (
+	CodeSuccess               = 0
+	CodeInvalidFilter         = -2015
+	CodeMismatchedDimension   = -2019
+	CodeInexistentCollection  = -2021
+	CodeInexistentPartition   = -2022
+	CodeDuplicateCollection   = -2025
+	CodeDuplicatePartition    = -2026
+	CodeDuplicateKey          = -2027
+	CodeUnreadyPartition      = -2029
+	CodeUnreadyCollection     = -2030
+	CodeExceedRateLimit       = -2034
+	CodeInvalidBatchSize      = -2036
+	CodeInvalidDimension      = -2037
+	CodeInvalidTopk           = -2041
+	CodeExceedPartitionLimit  = -2956
+	CodeExceedCollectionLimit = -2957
+	CodeExceedQuota           = -2960
+)
+
+//goland:noinspection GoUnusedGlobalVariable
+var (
+	ErrCollectionNotFound      = &Error{Code: CodeInexistentCollection}
+	ErrPartitionNotFound       = &Error{Code: CodeInexistentPartition}
+	ErrCollectionExists        = &Error{Code: CodeDuplicateCollection}
+	ErrPartitionExists         = &Error{Code: CodeDuplicatePartition}
+	ErrDuplicateKey            = &Error{Code: CodeDuplicateKey}
+	ErrMismatchedDimension     = &Error{Code: CodeMismatchedDimension}
+	ErrInvalidDimension        = &Error{Code: CodeInvalidDimension}
+	ErrInvalidFilter           = &Error{Code: CodeInvalidFilter}
+	ErrInvalidTopk             = &Error{Code: CodeInvalidTopk}
+	ErrInvalidBatchSize        = &Error{Code: CodeInvalidBatchSize}
+	ErrRateLimitExceeded       = &Error{Code: CodeExceedRateLimit}
+	ErrPartitionLimitExceeded  = &Error{Code: CodeExceedPartitionLimit}
+	ErrCollectionLimitExceeded = &Error{Code: CodeExceedCollectionLimit}
+	ErrQuotaExceeded           = &Error{Code: CodeExceedQuota}
+)
+
+// Error reports a DashVector response with a non-zero code.
+// Sentinel errors only compare by Code, so errors.Is(err, ErrCollectionNotFound)
+// matches any Error returned for a missing collection.
+type Error struct {
+	Code       int
+	Message    string
+	RequestId  string
+	StatusCode int
+	Operation  Operation
+}
+
+func newError(operation Operation, statusCode int, response Response) *Error {
+	return &Error{
+		Code:       response.GetCode(),
+		Message:    response.GetMessage(),
+		RequestId:  response.GetRequestId(),
+		StatusCode: statusCode,
+		Operation:  operation,
+	}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("dashvector %s failed: code=%d, message=%s, request_id=%s, status=%d",
+		e.Operation, e.Code, e.Message, e.RequestId, e.StatusCode)
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func (e *Error) GetCode() int {
+	return e.Code
+}
+
+func (e *Error) GetMessage() string {
+	return e.Message
+}
+
+func (e *Error) GetRequestId() string {
+	return e.RequestId
+}
+
+const maxResponseErrorBodyLength = 512
+
+// ResponseError reports an HTTP response which is not a DashVector envelope,
+// e.g. an HTML error page or an empty body returned by a gateway.
+type ResponseError struct {
+	StatusCode  int
+	ContentType string
+	Method      string
+	Endpoint    string
+	Body        string
+	Operation   Operation
+}
+
+func newResponseError(operation Operation, response *http.Response, body []byte) *ResponseError {
+	err := &ResponseError{
+		StatusCode:  response.StatusCode,
+		ContentType: response.Header.Get(headerContentType),
+		Body:        truncateBody(body),
+		Operation:   operation,
+	}
+	if request := response.Request; request != nil {
+		err.Method = request.Method
+		err.Endpoint = request.URL.String()
+	}
+	return err
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("dashvector %s failed: unexpected response %d %s from %s %s, content-type=%q, body=%q",
+		e.Operation, e.StatusCode, http.StatusText(e.StatusCode), e.Method, e.Endpoint, e.ContentType, e.Body)
+}
+
+func truncateBody(body []byte) string {
+	if len(body) <= maxResponseErrorBodyLength {
+		return string(body)
+	}
+	return string(body[:maxResponseErrorBodyLength]) + "...(truncated)"
+}
+
+type PartialWriteError struct {
+	Code      int
+	Message   string
+	RequestId string
+	Total     int
+	Failures  []DocOpResult
+}
+
+func (e *PartialWriteError) Error() string {
+	failures := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		failures = append(failures, fmt.Sprintf("%s(code=%d, message=%s)",
+			failure.GetId(), failure.GetCode(), failure.GetMessage()))
+	}
+	return fmt.Sprintf("dashvector write failed for %d of %d docs: code=%d, message=%s, request_id=%s, failures=[%s]",
+		len(e.Failures), e.Total, e.Code, e.Message, e.RequestId, strings.Join(failures, ", "))
+}
+
+type DocumentViolation struct {
+	Index  int
+	Id     string
+	Reason string
+}
+
+type ValidationError struct {
+	Violations []DocumentViolation
+}
+
+func (e *ValidationError) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		violations = append(violations, fmt.Sprintf("[%d] id=%s: %s",
+			violation.Index, violation.Id, violation.Reason))
+	}
+	return fmt.Sprintf("dashvector docs validation failed: %s", strings.Join(violations, "; "))
+}
+
+// BudgetExceededError reports an operation rejected by a UsageMeter budget.
+type BudgetExceededError struct {
+	Operation  Operation
+	Unit       string
+	Limit      int64
+	Window     time.Duration
+	RetryAfter time.Duration
+}
+
+func (e *BudgetExceededError) Error() string {
+	if e.Window <= 0 {
+		return fmt.Sprintf("dashvector %s rejected: %s unit budget %d exceeded",
+			e.Operation, e.Unit, e.Limit)
+	}
+	return fmt.Sprintf("dashvector %s rejected: %s unit budget %d per %s exceeded, retry after %s",
+		e.Operation, e.Unit, e.Limit, e.Window, e.RetryAfter)
+}
@@ -0,0 +1,42 @@
+package dashvector
+
+import (
+	"context"
+)
+
+// Invocation describes one API call passing through the interceptor chain.
+// Interceptors may modify Request and Header before calling the next Invoker.
+type Invocation struct {
+	Operation  Operation
+	Collection string
+	Partition  string
+	Method     string
+	Url        string
+	Request    any
+	Header     map[string]string
+}
+
+type Invoker func(ctx context.Context, invocation *Invocation) (Response, error)
+
+// Interceptor wraps every operation, including retries and the CodeError conversion.
+// The Response passed back is the decoded response, nil when the request failed.
+type Interceptor interface {
+	Intercept(ctx context.Context, invocation *Invocation, next Invoker) (Response, error)
+}
+
+type InterceptorFunc func(ctx context.Context, invocation *Invocation, next Invoker) (Response, error)
+
+func (f InterceptorFunc) Intercept(ctx context.Context, invocation *Invocation, next Invoker) (Response, error) {
+	return f(ctx, invocation, next)
+}
+
+// chainInterceptors wraps invoker so that interceptors run in the given order, the first outermost.
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, invocation *Invocation) (Response, error) {
+			return interceptor.Intercept(ctx, invocation, next)
+		}
+	}
+	return invoker
+}
@@ -0,0 +1,78 @@
+package dashvector
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/CharLemAznable/dashvector-sdk-go/internal/inmemory"
+	"io"
+	"net/http"
+)
+
+const (
+	localClusterEndpoint = "local"
+	localApiKey          = "local"
+)
+
+// NewLocalClient returns a Client backed by an in-memory store with brute-force search.
+// Requests are still encoded and sent through the SDK's HTTP client, but the transport
+// hands them to the in-memory handler in the same goroutine instead of the network,
+// so ClientWithHttpClient and ClientWithScheme are rejected.
+func NewLocalClient(configs ...ClientConfig) (Client, error) {
+	config := newClientConfig(configs...)
+	if err := validateLocalClientConfig(config); err != nil {
+		return nil, err
+	}
+	config.Scheme = schemeHttp
+	config.HttpClient = &http.Client{Transport: &localTransport{handler: inmemory.New(localApiKey)}}
+	return newCollections(newExecutor(newHttpClient(localClusterEndpoint, localApiKey, config), config)), nil
+}
+
+type localTransport struct {
+	handler http.Handler
+}
+
+func (t *localTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		defer func() { _ = request.Body.Close() }()
+	}
+	if err := request.Context().Err(); err != nil {
+		return nil, err
+	}
+	writer := &localResponseWriter{header: make(http.Header)}
+	t.handler.ServeHTTP(writer, request)
+	if writer.statusCode == 0 {
+		writer.statusCode = http.StatusOK
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", writer.statusCode, http.StatusText(writer.statusCode)),
+		StatusCode:    writer.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        writer.header,
+		Body:          io.NopCloser(&writer.body),
+		ContentLength: int64(writer.body.Len()),
+		Request:       request,
+	}, nil
+}
+
+type localResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *localResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *localResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *localResponseWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(data)
+}
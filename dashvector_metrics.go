@@ -0,0 +1,61 @@
+package dashvector
+
+import (
+	"context"
+	"time"
+)
+
+// Observation is the outcome of one operation, reported to Metrics.
+type Observation struct {
+	Operation   Operation
+	Collection  string
+	Duration    time.Duration
+	Code        int
+	Err         error
+	DocsWritten int
+	ReadUnits   int
+	WriteUnits  int
+}
+
+// Failed reports whether the operation returned an error or a non-zero response code.
+func (o *Observation) Failed() bool {
+	return o.Err != nil || o.Code != CodeSuccess
+}
+
+// Metrics records one Observation per operation; implementations must be safe for concurrent use.
+type Metrics interface {
+	Observe(ctx context.Context, observation *Observation)
+}
+
+type MetricsFunc func(ctx context.Context, observation *Observation)
+
+func (f MetricsFunc) Observe(ctx context.Context, observation *Observation) {
+	f(ctx, observation)
+}
+
+type metricsInterceptor struct {
+	metrics Metrics
+}
+
+func (i *metricsInterceptor) Intercept(ctx context.Context, invocation *Invocation, next Invoker) (Response, error) {
+	start := time.Now()
+	response, err := next(ctx, invocation)
+	observation := &Observation{
+		Operation:  invocation.Operation,
+		Collection: invocation.Collection,
+		Duration:   time.Since(start),
+		Err:        err,
+	}
+	if response != nil {
+		observation.Code = response.GetCode()
+		if usage, ok := response.(usageResponse); ok && usage.GetUsage() != nil {
+			observation.ReadUnits = usage.GetUsage().GetReadUnits()
+			observation.WriteUnits = usage.GetUsage().GetWriteUnits()
+		}
+		if writeResponse, ok := response.(DocumentsWriteResponse); ok {
+			observation.DocsWritten = len(writeResponse.Succeeded())
+		}
+	}
+	i.metrics.Observe(ctx, observation)
+	return response, err
+}
@@ -0,0 +1,190 @@
+package dashvector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var defaultPrometheusBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type PrometheusConfig func(*PrometheusMetrics)
+
+func PrometheusWithNamespace(namespace string) PrometheusConfig {
+	return func(metrics *PrometheusMetrics) {
+		metrics.namespace = namespace
+	}
+}
+
+// PrometheusWithBuckets sets the upper bounds in seconds of the latency histogram buckets.
+func PrometheusWithBuckets(buckets ...float64) PrometheusConfig {
+	return func(metrics *PrometheusMetrics) {
+		metrics.buckets = append([]float64(nil), buckets...)
+		sort.Float64s(metrics.buckets)
+	}
+}
+
+// PrometheusMetrics is a Metrics aggregating observations by operation and collection,
+// exposed in the Prometheus text format through WriteTo or as an http.Handler.
+type PrometheusMetrics struct {
+	namespace string
+	buckets   []float64
+	mutex     sync.Mutex
+	series    map[prometheusLabels]*prometheusSeries
+	codes     map[prometheusCodeLabels]int64
+}
+
+func NewPrometheusMetrics(configs ...PrometheusConfig) *PrometheusMetrics {
+	metrics := &PrometheusMetrics{
+		namespace: "dashvector",
+		buckets:   defaultPrometheusBuckets,
+		series:    make(map[prometheusLabels]*prometheusSeries),
+		codes:     make(map[prometheusCodeLabels]int64),
+	}
+	for _, cfg := range configs {
+		cfg(metrics)
+	}
+	return metrics
+}
+
+type prometheusLabels struct {
+	operation  Operation
+	collection string
+}
+
+type prometheusCodeLabels struct {
+	prometheusLabels
+	code int
+}
+
+type prometheusSeries struct {
+	requests     int64
+	errors       int64
+	bucketCounts []int64
+	durationSum  float64
+	docsWritten  int64
+	readUnits    int64
+	writeUnits   int64
+}
+
+func (m *PrometheusMetrics) Observe(_ context.Context, observation *Observation) {
+	labels := prometheusLabels{operation: observation.Operation, collection: observation.Collection}
+	seconds := observation.Duration.Seconds()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	series, ok := m.series[labels]
+	if !ok {
+		series = &prometheusSeries{bucketCounts: make([]int64, len(m.buckets))}
+		m.series[labels] = series
+	}
+	series.requests++
+	if observation.Failed() {
+		series.errors++
+	}
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			series.bucketCounts[i]++
+		}
+	}
+	series.durationSum += seconds
+	series.docsWritten += int64(observation.DocsWritten)
+	series.readUnits += int64(observation.ReadUnits)
+	series.writeUnits += int64(observation.WriteUnits)
+	if observation.Code != CodeSuccess {
+		m.codes[prometheusCodeLabels{prometheusLabels: labels, code: observation.Code}]++
+	}
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format.
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	var buffer bytes.Buffer
+	m.mutex.Lock()
+	labels := make([]prometheusLabels, 0, len(m.series))
+	for l := range m.series {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].less(labels[j]) })
+	codes := make([]prometheusCodeLabels, 0, len(m.codes))
+	for l := range m.codes {
+		codes = append(codes, l)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].prometheusLabels != codes[j].prometheusLabels {
+			return codes[i].prometheusLabels.less(codes[j].prometheusLabels)
+		}
+		return codes[i].code < codes[j].code
+	})
+
+	m.writeCounter(&buffer, "requests_total", "Total number of operations.", labels,
+		func(s *prometheusSeries) int64 { return s.requests })
+	m.writeCounter(&buffer, "request_errors_total", "Total number of operations failed with an error or a non-zero response code.", labels,
+		func(s *prometheusSeries) int64 { return s.errors })
+	name := m.namespace + "_response_codes_total"
+	fmt.Fprintf(&buffer, "# HELP %s Total number of responses with a non-zero code.\n# TYPE %s counter\n", name, name)
+	for _, l := range codes {
+		fmt.Fprintf(&buffer, "%s{%s,code=\"%d\"} %d\n", name, l.prometheusLabels.format(), l.code, m.codes[l])
+	}
+	name = m.namespace + "_request_duration_seconds"
+	fmt.Fprintf(&buffer, "# HELP %s Latency of operations in seconds.\n# TYPE %s histogram\n", name, name)
+	for _, l := range labels {
+		series := m.series[l]
+		for i, bound := range m.buckets {
+			fmt.Fprintf(&buffer, "%s_bucket{%s,le=\"%s\"} %d\n", name, l.format(), formatFloat(bound), series.bucketCounts[i])
+		}
+		fmt.Fprintf(&buffer, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, l.format(), series.requests)
+		fmt.Fprintf(&buffer, "%s_sum{%s} %s\n", name, l.format(), formatFloat(series.durationSum))
+		fmt.Fprintf(&buffer, "%s_count{%s} %d\n", name, l.format(), series.requests)
+	}
+	m.writeCounter(&buffer, "docs_written_total", "Total number of docs written successfully.", labels,
+		func(s *prometheusSeries) int64 { return s.docsWritten })
+	m.writeCounter(&buffer, "read_units_total", "Total read units consumed.", labels,
+		func(s *prometheusSeries) int64 { return s.readUnits })
+	m.writeCounter(&buffer, "write_units_total", "Total write units consumed.", labels,
+		func(s *prometheusSeries) int64 { return s.writeUnits })
+	m.mutex.Unlock()
+	return buffer.WriteTo(w)
+}
+
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", prometheusContentType)
+	_, _ = m.WriteTo(w)
+}
+
+func (m *PrometheusMetrics) writeCounter(buffer *bytes.Buffer, name, help string,
+	labels []prometheusLabels, value func(*prometheusSeries) int64) {
+	name = m.namespace + "_" + name
+	fmt.Fprintf(buffer, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, l := range labels {
+		fmt.Fprintf(buffer, "%s{%s} %d\n", name, l.format(), value(m.series[l]))
+	}
+}
+
+func (l prometheusLabels) less(other prometheusLabels) bool {
+	if l.operation != other.operation {
+		return l.operation < other.operation
+	}
+	return l.collection < other.collection
+}
+
+func (l prometheusLabels) format() string {
+	return fmt.Sprintf("operation=\"%s\",collection=\"%s\"",
+		escapeLabelValue(string(l.operation)), escapeLabelValue(l.collection))
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
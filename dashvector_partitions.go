@@ -2,26 +2,29 @@ package dashvector
 
 import (
 	"context"
-	"github.com/CharLemAznable/gfx/net/gclientx"
+	"errors"
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/encoding/gjson"
-	"time"
+	"github.com/samber/lo"
+	"net/http"
 )
 
-func newPartitions(client *gclientx.Client, collectionName string) Collection {
+func newPartitions(executor *executor, collectionName string) Collection {
 	p := &partitions{
-		Client:         client,
+		executor:       executor.scope(collectionName, ""),
 		collectionName: collectionName,
 		partitionsMap:  gmap.NewStrAnyMap(true),
+		schema:         &collectionSchema{},
 	}
 	p.Partition = p.GetPartition()
 	return p
 }
 
 type partitions struct {
-	*gclientx.Client
+	*executor
 	collectionName string
 	partitionsMap  *gmap.StrAnyMap
+	schema         *collectionSchema
 	Partition
 }
 
@@ -30,32 +33,32 @@ func (p *partitions) Create(ctx context.Context, partitionName string) (Response
 		return nil, err
 	}
 	request := newPartitionCreateRequest(partitionName)
-	return decode(parseResponse, p.PostBytes, ctx, "/collections/"+p.collectionName+"/partitions", request)
+	return decode(p.scope(p.collectionName, partitionName), OperationPartitionCreate, parseResponse, ctx, http.MethodPost, "/collections/"+p.collectionName+"/partitions", request)
 }
 
 func (p *partitions) Desc(ctx context.Context, partitionName string) (PartitionDescResponse, error) {
 	if err := validatePartitionName(ctx, partitionName); err != nil {
 		return nil, err
 	}
-	return decode(parsePartitionDescResponse, p.GetBytes, ctx, "/collections/"+p.collectionName+"/partitions/"+partitionName)
+	return decode(p.scope(p.collectionName, partitionName), OperationPartitionDesc, parsePartitionDescResponse, ctx, http.MethodGet, "/collections/"+p.collectionName+"/partitions/"+partitionName)
 }
 
 func (p *partitions) List(ctx context.Context) (PartitionListResponse, error) {
-	return decode(parsePartitionListResponse, p.GetBytes, ctx, "/collections/"+p.collectionName+"/partitions")
+	return decode(p.executor, OperationPartitionList, parsePartitionListResponse, ctx, http.MethodGet, "/collections/"+p.collectionName+"/partitions")
 }
 
 func (p *partitions) Stats(ctx context.Context, partitionName string) (PartitionStatsResponse, error) {
 	if err := validatePartitionName(ctx, partitionName); err != nil {
 		return nil, err
 	}
-	return decode(parsePartitionStatsResponse, p.GetBytes, ctx, "/collections/"+p.collectionName+"/partitions/"+partitionName+"/stats")
+	return decode(p.scope(p.collectionName, partitionName), OperationPartitionStats, parsePartitionStatsResponse, ctx, http.MethodGet, "/collections/"+p.collectionName+"/partitions/"+partitionName+"/stats")
 }
 
 func (p *partitions) Delete(ctx context.Context, partitionName string) (Response, error) {
 	if err := validatePartitionName(ctx, partitionName); err != nil {
 		return nil, err
 	}
-	return decode(parseResponse, p.DeleteBytes, ctx, "/collections/"+p.collectionName+"/partitions/"+partitionName)
+	return decode(p.scope(p.collectionName, partitionName), OperationPartitionDelete, parseResponse, ctx, http.MethodDelete, "/collections/"+p.collectionName+"/partitions/"+partitionName)
 }
 
 func (p *partitions) CreateServing(ctx context.Context, partitionName string) (Response, error) {
@@ -63,13 +66,88 @@ func (p *partitions) CreateServing(ctx context.Context, partitionName string) (R
 	if err != nil || createResponse.GetCode() != 0 {
 		return createResponse, err
 	}
-	for {
-		time.Sleep(time.Millisecond * 10)
-		descResponse, err := p.Desc(ctx, partitionName)
-		if err != nil || descResponse.GetOutput() == StatusServing {
-			return descResponse, err
+	descResponse, err := p.WaitForPartitionStatus(ctx, partitionName, StatusServing)
+	if descResponse == nil {
+		return createResponse, err
+	}
+	return descResponse, err
+}
+
+func (p *partitions) DeleteServing(ctx context.Context, partitionName string) (Response, error) {
+	deleteResponse, err := p.Delete(ctx, partitionName)
+	if err != nil || deleteResponse.GetCode() != 0 {
+		return deleteResponse, err
+	}
+	return deleteResponse, p.WaitUntilGone(ctx, partitionName)
+}
+
+func (p *partitions) EnsurePartition(ctx context.Context, partitionName string) (Response, error) {
+	descResponse, err := p.Desc(ctx, partitionName)
+	if errors.Is(err, ErrPartitionNotFound) ||
+		(err == nil && descResponse.GetCode() == CodeInexistentPartition) {
+		return p.CreateServing(ctx, partitionName)
+	}
+	if err != nil || descResponse.GetCode() != 0 || descResponse.GetOutput() == StatusServing {
+		return descResponse, err
+	}
+	return p.WaitForPartitionStatus(ctx, partitionName, StatusServing)
+}
+
+func (p *partitions) WaitForPartitionStatus(ctx context.Context, partitionName string, status Status) (PartitionDescResponse, error) {
+	if err := validatePartitionName(ctx, partitionName); err != nil {
+		return nil, err
+	}
+	var descResponse PartitionDescResponse
+	err := p.config.Wait.poll(ctx, func(ctx context.Context) (done bool, err error) {
+		descResponse, err = p.Desc(ctx, partitionName)
+		if err != nil {
+			return true, err
 		}
+		if descResponse.GetCode() != CodeSuccess {
+			return true, newError(OperationPartitionDesc, 0, descResponse)
+		}
+		switch current := descResponse.GetOutput(); current {
+		case status:
+			return true, nil
+		case StatusError:
+			return true, &FailedStatusError{Collection: p.collectionName, Partition: partitionName, Status: current}
+		}
+		return false, nil
+	})
+	return descResponse, err
+}
+
+func (p *partitions) WaitUntilGone(ctx context.Context, partitionName string) error {
+	if err := validatePartitionName(ctx, partitionName); err != nil {
+		return err
+	}
+	return p.config.Wait.poll(ctx, func(ctx context.Context) (bool, error) {
+		listResponse, err := p.List(ctx)
+		if err != nil {
+			return true, err
+		}
+		if listResponse.GetCode() != CodeSuccess {
+			return true, newError(OperationPartitionList, 0, listResponse)
+		}
+		return !lo.Contains(listResponse.GetOutput(), partitionName), nil
+	})
+}
+
+func (p *partitions) LoadSchema(ctx context.Context) (CollectionMeta, error) {
+	descResponse, err := decode(p.executor, OperationCollectionDesc, parseCollectionDescResponse, ctx, http.MethodGet, "/collections/"+p.collectionName)
+	if err != nil {
+		return nil, err
 	}
+	if descResponse.GetCode() != CodeSuccess {
+		return nil, newError(OperationCollectionDesc, 0, descResponse)
+	}
+	meta := descResponse.GetOutput()
+	p.schema.set(meta)
+	return meta, nil
+}
+
+func (p *partitions) ClearSchema() {
+	p.schema.set(nil)
 }
 
 const defaultPartitionName = "default"
@@ -80,7 +158,7 @@ func (p *partitions) GetPartition(partitionName ...string) Partition {
 		name = partitionName[0]
 	}
 	return p.partitionsMap.GetOrSetFuncLock(name, func() any {
-		return newDocuments(p.Client, p.collectionName, name)
+		return newDocuments(p.executor, p.collectionName, name, p.schema)
 	}).(Partition)
 }
 
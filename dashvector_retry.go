@@ -0,0 +1,104 @@
+package dashvector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseDelay   = time.Millisecond * 100
+	defaultRetryMaxDelay    = time.Second * 2
+	defaultRetryJitter      = 0.2
+)
+
+var nonIdempotentOperations = map[Operation]bool{
+	OperationCollectionCreate: true,
+	OperationPartitionCreate:  true,
+	OperationDocsInsert:       true,
+}
+
+func newRetryPolicy(configs ...RetryConfig) *retryPolicy {
+	policy := &retryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+		Jitter:      defaultRetryJitter,
+		Codes:       map[int]bool{CodeExceedRateLimit: true},
+	}
+	for _, cfg := range configs {
+		cfg(policy)
+	}
+	return policy
+}
+
+type retryPolicy struct {
+	MaxAttempts   int
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+	Jitter        float64
+	Codes         map[int]bool
+	NonIdempotent bool
+}
+
+func (p *retryPolicy) shouldRetry(ctx context.Context, operation Operation,
+	attempt int, statusCode int, code int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if nonIdempotentOperations[operation] && !p.NonIdempotent {
+		return false
+	}
+	if err != nil {
+		var responseErr *ResponseError
+		if errors.As(err, &responseErr) {
+			return retryableStatus(responseErr.StatusCode)
+		}
+		return retryableError(err)
+	}
+	return code != CodeSuccess && (p.Codes[code] || retryableStatus(statusCode))
+}
+
+func (p *retryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.delay(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (p *retryPolicy) delay(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+func retryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusInternalServerError ||
+		statusCode == http.StatusBadGateway ||
+		statusCode == http.StatusServiceUnavailable ||
+		statusCode == http.StatusGatewayTimeout
+}
+
+func retryableError(err error) bool {
+	var netErr net.Error
+	return (errors.As(err, &netErr) && netErr.Timeout()) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
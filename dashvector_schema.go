@@ -0,0 +1,256 @@
+package dashvector
+
+import (
+	"fmt"
+	"github.com/CharLemAznable/dashvector-sdk-go/filter"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/samber/lo"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+const defaultVectorName = "proxima_vector"
+
+type SchemaDiff struct {
+	Field   string
+	Desired string
+	Actual  string
+}
+
+type SchemaMismatchError struct {
+	Collection string
+	Diffs      []SchemaDiff
+}
+
+func (e *SchemaMismatchError) Error() string {
+	return fmt.Sprintf("dashvector collection %s schema mismatch: %s", e.Collection,
+		gstr.Join(lo.Map(e.Diffs, func(diff SchemaDiff, _ int) string {
+			return fmt.Sprintf("%s desired %q actual %q", diff.Field, diff.Desired, diff.Actual)
+		}), ", "))
+}
+
+func diffCollectionSchema(request *collectionCreateRequest, meta CollectionMeta) []SchemaDiff {
+	var diffs []SchemaDiff
+	add := func(field string, desired, actual any) {
+		if d, a := fmt.Sprint(desired), fmt.Sprint(actual); d != a {
+			diffs = append(diffs, SchemaDiff{Field: field, Desired: d, Actual: a})
+		}
+	}
+	// unset values are left to the server defaults, so they match whatever the server chose
+	addIfSet := func(field string, desired, actual any) {
+		if fmt.Sprint(desired) != "" {
+			add(field, desired, actual)
+		}
+	}
+	if len(request.VectorsSchema) == 0 {
+		add("dimension", request.Dimension, meta.GetDimension())
+		addIfSet("dtype", request.DataType, meta.GetDataType())
+		addIfSet("metric", request.Metric, meta.GetMetric())
+		var desiredQuantize, actualQuantize QuantizeType
+		if request.ExtraParams != nil {
+			desiredQuantize = request.ExtraParams.QuantizeType
+		}
+		if schema, ok := meta.GetVectorsSchema()[defaultVectorName]; ok {
+			actualQuantize = schema.GetQuantizeType()
+		}
+		addIfSet("quantize_type", desiredQuantize, actualQuantize)
+	} else {
+		actualVectors := meta.GetVectorsSchema()
+		for _, name := range sortedKeys(request.VectorsSchema, actualVectors) {
+			desired, actual := request.VectorsSchema[name], actualVectors[name]
+			switch {
+			case desired == nil:
+				add("vectors_schema."+name, "", "present")
+			case actual == nil:
+				add("vectors_schema."+name, "present", "")
+			default:
+				add("vectors_schema."+name+".dimension", desired.Dimension, actual.GetDimension())
+				addIfSet("vectors_schema."+name+".dtype", desired.DataType, actual.GetDataType())
+				addIfSet("vectors_schema."+name+".metric", desired.Metric, actual.GetMetric())
+				addIfSet("vectors_schema."+name+".quantize_type", desired.QuantizeType, actual.GetQuantizeType())
+			}
+		}
+	}
+	actualFields := meta.GetFieldsSchema()
+	for _, name := range sortedKeys(request.FieldsSchema, actualFields) {
+		add("fields_schema."+name, request.FieldsSchema[name], actualFields[name])
+	}
+	return diffs
+}
+
+func sortedKeys[A, B any](a map[string]A, b map[string]B) []string {
+	keys := lo.Uniq(append(lo.Keys(a), lo.Keys(b)...))
+	sort.Strings(keys)
+	return keys
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type collectionSchema struct {
+	mutex sync.RWMutex
+	meta  CollectionMeta
+}
+
+func (s *collectionSchema) get() CollectionMeta {
+	if s == nil {
+		return nil
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.meta
+}
+
+func (s *collectionSchema) set(meta CollectionMeta) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.meta = meta
+}
+
+func newSchemaValidationError(collectionName string, reason string) error {
+	return gerror.NewCodef(gcode.CodeInvalidParameter, "collection %s: %s", collectionName, reason)
+}
+
+func schemaDocumentValidator(meta CollectionMeta, validate func(*doc) string) func(*doc) string {
+	return func(document *doc) string {
+		if reason := validate(document); reason != "" {
+			return reason
+		}
+		return validateDocumentSchema(meta, document)
+	}
+}
+
+func validateDocumentSchema(meta CollectionMeta, document *doc) string {
+	if len(document.Vector) > 0 {
+		if reason := validateVectorSchema(meta, "", document.Vector); reason != "" {
+			return reason
+		}
+	}
+	names := lo.Keys(document.Vectors)
+	sort.Strings(names)
+	for _, name := range names {
+		if reason := validateVectorSchema(meta, name, document.Vectors[name]); reason != "" {
+			return reason
+		}
+	}
+	names = lo.Keys(document.Fields)
+	sort.Strings(names)
+	for _, name := range names {
+		if reason := validateFieldSchema(meta, name, document.Fields[name]); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+func validateQuerySchema(meta CollectionMeta, request *documentsQueryRequest) string {
+	if len(request.Vector) > 0 {
+		if reason := validateVectorSchema(meta, "", request.Vector); reason != "" {
+			return reason
+		}
+	}
+	names := lo.Keys(request.Vectors)
+	sort.Strings(names)
+	for _, name := range names {
+		var vector []float32
+		if query := request.Vectors[name]; query != nil {
+			vector = query.Vector
+		}
+		if reason := validateVectorSchema(meta, name, vector); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+func validateGroupQuerySchema(meta CollectionMeta, request *documentsGroupQueryRequest) string {
+	if len(request.Vector) > 0 || request.VectorField != "" {
+		return validateVectorSchema(meta, request.VectorField, request.Vector)
+	}
+	return ""
+}
+
+func validateFilterSchema(meta CollectionMeta, filterString string) error {
+	if filterString == "" {
+		return nil
+	}
+	expr, err := filter.Parse(filterString)
+	if err != nil || len(meta.GetFieldsSchema()) == 0 {
+		return err
+	}
+	return filter.Validate(expr, lo.MapValues(meta.GetFieldsSchema(),
+		func(fieldType FieldType, _ string) string { return string(fieldType) }))
+}
+
+func validateVectorSchema(meta CollectionMeta, name string, vector []float32) string {
+	label := lo.Ternary(name == "", "vector", "vector "+name)
+	dimension, ok := schemaVectorDimension(meta, name)
+	if !ok {
+		return label + " is not defined in collection schema"
+	}
+	if len(vector) > 0 && dimension > 0 && len(vector) != dimension {
+		return fmt.Sprintf("%s has dimension %d, expected %d", label, len(vector), dimension)
+	}
+	return ""
+}
+
+func schemaVectorDimension(meta CollectionMeta, name string) (int, bool) {
+	vectorsSchema := meta.GetVectorsSchema()
+	if name == "" {
+		if schema, ok := vectorsSchema[defaultVectorName]; ok {
+			return schema.GetDimension(), true
+		}
+		return meta.GetDimension(), meta.GetDimension() > 0 || len(vectorsSchema) == 0
+	}
+	schema, ok := vectorsSchema[name]
+	if !ok {
+		return 0, false
+	}
+	return schema.GetDimension(), true
+}
+
+func validateFieldSchema(meta CollectionMeta, name string, value any) string {
+	fieldType, ok := meta.GetFieldsSchema()[name]
+	if !ok || value == nil {
+		return ""
+	}
+	valueType, ok := fieldTypeOfKind(reflect.Indirect(reflect.ValueOf(value)).Kind())
+	if ok && (valueType == fieldType || (fieldType == FieldTypeFloat && valueType == FieldTypeInt)) {
+		return ""
+	}
+	return fmt.Sprintf("field %s has type %T, expected %s", name, value, fieldType)
+}
+
+func decodeDocsSchema(meta CollectionMeta, docs ...Doc) {
+	fieldsSchema := meta.GetFieldsSchema()
+	if len(fieldsSchema) == 0 {
+		return
+	}
+	for _, d := range docs {
+		document, ok := d.(*doc)
+		if !ok {
+			continue
+		}
+		for name, value := range document.Fields {
+			if fieldType, ok := fieldsSchema[name]; ok {
+				document.Fields[name] = decodeFieldValue(fieldType, value)
+			}
+		}
+	}
+}
+
+func decodeFieldValue(fieldType FieldType, value any) any {
+	switch fieldType {
+	case FieldTypeInt:
+		if i, ok := fieldInt64(value); ok {
+			return i
+		}
+	case FieldTypeFloat:
+		if f, ok := fieldFloat64(value); ok {
+			return f
+		}
+	}
+	return value
+}
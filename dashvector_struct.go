@@ -0,0 +1,223 @@
+package dashvector
+
+import (
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const structTagName = "dashvector"
+
+type structFieldKind int
+
+const (
+	structFieldKindField structFieldKind = iota
+	structFieldKindId
+	structFieldKindVector
+	structFieldKindSparse
+)
+
+func StructSchema(v any) (CollectionConfig, error) {
+	schema, err := parseStructSchema(reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+	var configs []CollectionConfig
+	for _, field := range schema.Fields {
+		switch field.Kind {
+		case structFieldKindField:
+			configs = append(configs, WithFieldSchema(field.Name, field.FieldType))
+		case structFieldKindVector:
+			if field.Name == "" {
+				configs = append(configs, WithDimension(field.Dimension))
+				if field.DataType != "" {
+					configs = append(configs, WithDataType(field.DataType))
+				}
+				if field.Metric != "" {
+					configs = append(configs, WithMetric(field.Metric))
+				}
+				if field.QuantizeType != "" {
+					configs = append(configs, WithExtraParams(WithQuantizeType(field.QuantizeType)))
+				}
+				continue
+			}
+			var vectorConfigs []VectorSchemaConfig
+			if field.DataType != "" {
+				vectorConfigs = append(vectorConfigs, WithVectorDataType(field.DataType))
+			}
+			if field.Metric != "" {
+				vectorConfigs = append(vectorConfigs, WithVectorMetric(field.Metric))
+			}
+			if field.QuantizeType != "" {
+				vectorConfigs = append(vectorConfigs, WithVectorQuantizeType(field.QuantizeType))
+			}
+			configs = append(configs, WithVectorSchema(field.Name, field.Dimension, vectorConfigs...))
+		}
+	}
+	return func(request *collectionCreateRequest) {
+		for _, cfg := range configs {
+			cfg(request)
+		}
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var structSchemaCache sync.Map
+
+type structSchema struct {
+	Fields []*structField
+}
+
+type structField struct {
+	Index        []int
+	Name         string
+	Kind         structFieldKind
+	FieldType    FieldType
+	Dimension    int
+	DataType     DataType
+	Metric       Metric
+	QuantizeType QuantizeType
+}
+
+func parseStructSchema(t reflect.Type) (*structSchema, error) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "struct type required: %v", t)
+	}
+	if cached, ok := structSchemaCache.Load(t); ok {
+		return cached.(*structSchema), nil
+	}
+	schema := &structSchema{}
+	if err := parseStructFields(t, nil, schema); err != nil {
+		return nil, err
+	}
+	structSchemaCache.Store(t, schema)
+	return schema, nil
+}
+
+func parseStructFields(t reflect.Type, index []int, schema *structSchema) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+		tag, tagged := sf.Tag.Lookup(structTagName)
+		if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct {
+			if err := parseStructFields(sf.Type, fieldIndex, schema); err != nil {
+				return err
+			}
+			continue
+		}
+		if !tagged || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		field, err := parseStructField(sf, tag)
+		if err != nil {
+			return err
+		}
+		field.Index = fieldIndex
+		schema.Fields = append(schema.Fields, field)
+	}
+	return nil
+}
+
+func parseStructField(sf reflect.StructField, tag string) (*structField, error) {
+	parts := strings.Split(tag, ",")
+	field := &structField{Name: gstr.Trim(parts[0]), Kind: structFieldKindField}
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(gstr.Trim(part), "=")
+		switch gstr.ToLower(key) {
+		case "id":
+			field.Kind = structFieldKindId
+		case "vector":
+			field.Kind = structFieldKindVector
+		case "sparse":
+			field.Kind = structFieldKindSparse
+		case "dim":
+			field.Dimension = gconv.Int(value)
+		case "dtype":
+			field.DataType = DataType(gstr.ToUpper(value))
+		case "metric":
+			field.Metric = Metric(gstr.ToLower(value))
+		case "quantize":
+			field.QuantizeType = QuantizeType(value)
+		default:
+			fieldType := FieldType(gstr.ToUpper(key))
+			if _, ok := fieldTypeKinds[fieldType]; !ok {
+				return nil, gerror.NewCodef(gcode.CodeInvalidParameter,
+					"field %s has unknown tag option %s", sf.Name, key)
+			}
+			field.FieldType = fieldType
+		}
+	}
+	fieldType := sf.Type
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	switch field.Kind {
+	case structFieldKindId:
+		if fieldType.Kind() != reflect.String {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter,
+				"id field %s must be string, got %s", sf.Name, sf.Type)
+		}
+	case structFieldKindVector:
+		if fieldType != reflect.TypeOf([]float32{}) {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter,
+				"vector field %s must be []float32, got %s", sf.Name, sf.Type)
+		}
+		if field.Dimension <= 0 {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter,
+				"vector field %s requires dim", sf.Name)
+		}
+	case structFieldKindSparse:
+		if fieldType != reflect.TypeOf(map[int32]float32{}) {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter,
+				"sparse vector field %s must be map[int32]float32, got %s", sf.Name, sf.Type)
+		}
+	default:
+		if field.Name == "" {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter,
+				"field %s requires name", sf.Name)
+		}
+		inferred, ok := fieldTypeOfKind(fieldType.Kind())
+		if !ok {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter,
+				"field %s has unsupported kind %s", sf.Name, sf.Type)
+		}
+		if field.FieldType == "" {
+			field.FieldType = inferred
+		} else if field.FieldType != inferred {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter,
+				"field %s declared as %s but has kind %s", sf.Name, field.FieldType, sf.Type)
+		}
+	}
+	return field, nil
+}
+
+var fieldTypeKinds = map[FieldType]struct{}{
+	FieldTypeBool:   {},
+	FieldTypeString: {},
+	FieldTypeInt:    {},
+	FieldTypeFloat:  {},
+}
+
+func fieldTypeOfKind(kind reflect.Kind) (FieldType, bool) {
+	switch kind {
+	case reflect.String:
+		return FieldTypeString, true
+	case reflect.Bool:
+		return FieldTypeBool, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return FieldTypeInt, true
+	case reflect.Float32, reflect.Float64:
+		return FieldTypeFloat, true
+	default:
+		return "", false
+	}
+}
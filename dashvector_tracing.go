@@ -0,0 +1,122 @@
+package dashvector
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentName = "github.com/CharLemAznable/dashvector-sdk-go"
+
+	tracingAttrDbSystem     = "db.system"
+	tracingAttrCollection   = "dashvector.collection"
+	tracingAttrPartition    = "dashvector.partition"
+	tracingAttrTopk         = "dashvector.topk"
+	tracingAttrDocCount     = "dashvector.doc_count"
+	tracingAttrResponseCode = "dashvector.response.code"
+	tracingAttrRequestId    = "dashvector.request_id"
+	tracingAttrReadUnits    = "dashvector.read_units"
+	tracingAttrWriteUnits   = "dashvector.write_units"
+	tracingAttrRetry        = "dashvector.retry"
+
+	tracingDbSystem    = "dashvector"
+	tracingChunkSuffix = ".chunk"
+)
+
+type usageResponse interface {
+	GetUsage() ResponseUsage
+}
+
+// tracingInterceptor starts one span per logical operation, named after the operation.
+// The span is carried in the context, so the HTTP request propagates it through gclient.
+type tracingInterceptor struct {
+	provider trace.TracerProvider
+}
+
+func (i *tracingInterceptor) Intercept(ctx context.Context, invocation *Invocation, next Invoker) (Response, error) {
+	return traceSpan(ctx, i.provider, string(invocation.Operation), requestAttributes(invocation),
+		func(ctx context.Context) (Response, error) { return next(ctx, invocation) })
+}
+
+// traceChunk starts a child span of the operation span for one request of a chunked write,
+// retry being the round of failed docs retry, 0 for the first attempt.
+func traceChunk(ctx context.Context, provider trace.TracerProvider, invocation *Invocation, retry int,
+	fn func(ctx context.Context) (Response, error)) (Response, error) {
+	attributes := append(requestAttributes(invocation), attribute.Int(tracingAttrRetry, retry))
+	return traceSpan(ctx, provider, string(invocation.Operation)+tracingChunkSuffix, attributes, fn)
+}
+
+func traceSpan(ctx context.Context, provider trace.TracerProvider, name string, attributes []attribute.KeyValue,
+	fn func(ctx context.Context) (Response, error)) (Response, error) {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	ctx, span := provider.Tracer(instrumentName).Start(ctx, name, trace.WithAttributes(attributes...))
+	defer span.End()
+
+	response, err := fn(ctx)
+	if response != nil {
+		span.SetAttributes(responseAttributes(response)...)
+		if response.GetCode() != CodeSuccess {
+			span.SetStatus(codes.Error, response.GetMessage())
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return response, err
+}
+
+func requestAttributes(invocation *Invocation) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{attribute.String(tracingAttrDbSystem, tracingDbSystem)}
+	if invocation.Collection != "" {
+		attributes = append(attributes, attribute.String(tracingAttrCollection, invocation.Collection))
+	}
+	if invocation.Partition != "" {
+		attributes = append(attributes, attribute.String(tracingAttrPartition, invocation.Partition))
+	}
+	switch request := invocation.Request.(type) {
+	case *documentsWriteRequest:
+		attributes = append(attributes, attribute.Int(tracingAttrDocCount, len(request.Docs)))
+	case *documentsDropRequest:
+		if !request.DeleteAll {
+			attributes = append(attributes, attribute.Int(tracingAttrDocCount, len(request.Ids)))
+		}
+	case *documentsQueryRequest:
+		attributes = append(attributes, attribute.Int(tracingAttrTopk, request.Topk))
+	case *documentsGroupQueryRequest:
+		attributes = append(attributes, attribute.Int(tracingAttrTopk, request.GroupTopk))
+	}
+	return attributes
+}
+
+// responseAttributes reports the response code and request id, the usage if any,
+// and for reads the number of docs returned.
+func responseAttributes(response Response) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attribute.Int(tracingAttrResponseCode, response.GetCode()),
+		attribute.String(tracingAttrRequestId, response.GetRequestId()),
+	}
+	if usage, ok := response.(usageResponse); ok && usage.GetUsage() != nil {
+		attributes = append(attributes,
+			attribute.Int(tracingAttrReadUnits, usage.GetUsage().GetReadUnits()),
+			attribute.Int(tracingAttrWriteUnits, usage.GetUsage().GetWriteUnits()))
+	}
+	switch r := response.(type) {
+	case DocumentsReadResponse:
+		attributes = append(attributes, attribute.Int(tracingAttrDocCount, len(r.GetOutput())))
+	case DocumentsQueryResponse:
+		attributes = append(attributes, attribute.Int(tracingAttrDocCount, len(r.GetOutput())))
+	case DocumentsGroupQueryResponse:
+		count := 0
+		for _, group := range r.GetOutput() {
+			count += len(group.GetDocs())
+		}
+		attributes = append(attributes, attribute.Int(tracingAttrDocCount, count))
+	}
+	return attributes
+}
@@ -0,0 +1,184 @@
+package dashvector
+
+import (
+	"context"
+	"github.com/gogf/gf/v2/util/gconv"
+	"reflect"
+)
+
+type TypedPartition[T any] struct {
+	partition Partition
+	schema    *structSchema
+}
+
+type Scored[T any] struct {
+	Doc   T
+	Score float32
+}
+
+func NewTypedPartition[T any](partition Partition) (*TypedPartition[T], error) {
+	schema, err := parseStructSchema(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return &TypedPartition[T]{partition: partition, schema: schema}, nil
+}
+
+func (p *TypedPartition[T]) Insert(ctx context.Context, docs ...T) (DocumentsWriteResponse, error) {
+	return p.partition.Insert(ctx, p.documents(docs)...)
+}
+
+func (p *TypedPartition[T]) Update(ctx context.Context, docs ...T) (DocumentsWriteResponse, error) {
+	return p.partition.Update(ctx, p.documents(docs)...)
+}
+
+func (p *TypedPartition[T]) Upsert(ctx context.Context, docs ...T) (DocumentsWriteResponse, error) {
+	return p.partition.Upsert(ctx, p.documents(docs)...)
+}
+
+func (p *TypedPartition[T]) Get(ctx context.Context, ids ...string) (map[string]T, error) {
+	readResponse, err := p.partition.Get(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	if readResponse.GetCode() != CodeSuccess {
+		return nil, newError(OperationDocsGet, 0, readResponse)
+	}
+	result := make(map[string]T, len(readResponse.GetOutput()))
+	for id, d := range readResponse.GetOutput() {
+		result[id] = p.decode(d)
+	}
+	return result, nil
+}
+
+func (p *TypedPartition[T]) Query(ctx context.Context, configs ...DocumentsQueryConfig) ([]Scored[T], error) {
+	queryResponse, err := p.partition.Query(ctx, configs...)
+	if err != nil {
+		return nil, err
+	}
+	if queryResponse.GetCode() != CodeSuccess {
+		return nil, newError(OperationDocsQuery, 0, queryResponse)
+	}
+	result := make([]Scored[T], 0, len(queryResponse.GetOutput()))
+	for _, d := range queryResponse.GetOutput() {
+		result = append(result, Scored[T]{Doc: p.decode(d), Score: d.GetScore()})
+	}
+	return result, nil
+}
+
+func (p *TypedPartition[T]) documents(docs []T) []DocumentsConfig {
+	configs := make([]DocumentsConfig, 0, len(docs))
+	for _, d := range docs {
+		configs = append(configs, WithDocument(p.encode(d)...))
+	}
+	return configs
+}
+
+func (p *TypedPartition[T]) encode(d T) []DocumentConfig {
+	value := reflect.Indirect(reflect.ValueOf(d))
+	if !value.IsValid() {
+		return nil
+	}
+	var configs []DocumentConfig
+	for _, field := range p.schema.Fields {
+		fieldValue, ok := fieldByIndex(value, field.Index)
+		if !ok {
+			continue
+		}
+		switch field.Kind {
+		case structFieldKindId:
+			configs = append(configs, WithId(fieldValue.String()))
+		case structFieldKindVector:
+			vector := fieldValue.Interface().([]float32)
+			if len(vector) == 0 {
+				continue
+			}
+			if field.Name == "" {
+				configs = append(configs, WithVector(vector...))
+			} else {
+				configs = append(configs, WithSchemaVector(field.Name, vector...))
+			}
+		case structFieldKindSparse:
+			for key, val := range fieldValue.Interface().(map[int32]float32) {
+				configs = append(configs, WithSparseVector(key, val))
+			}
+		default:
+			configs = append(configs, WithField(field.Name, fieldValue.Interface()))
+		}
+	}
+	return configs
+}
+
+func (p *TypedPartition[T]) decode(d Doc) T {
+	var result T
+	value := reflect.ValueOf(&result).Elem()
+	if value.Kind() == reflect.Pointer {
+		value.Set(reflect.New(value.Type().Elem()))
+		value = value.Elem()
+	}
+	for _, field := range p.schema.Fields {
+		var source any
+		switch field.Kind {
+		case structFieldKindId:
+			source = d.GetId()
+		case structFieldKindVector:
+			if field.Name == "" {
+				source = d.GetVector()
+			} else {
+				source = d.GetVectors()[field.Name]
+			}
+		case structFieldKindSparse:
+			source = d.GetSparseVector()
+		default:
+			source = d.GetFields()[field.Name]
+		}
+		if source == nil || reflect.ValueOf(source).IsZero() {
+			continue
+		}
+		setFieldValue(allocFieldByIndex(value, field.Index), source)
+	}
+	return result
+}
+
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for _, idx := range index {
+		value = value.Field(idx)
+	}
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+	return value, true
+}
+
+func allocFieldByIndex(value reflect.Value, index []int) reflect.Value {
+	for _, idx := range index {
+		value = value.Field(idx)
+	}
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+func setFieldValue(value reflect.Value, source any) {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(gconv.String(source))
+	case reflect.Bool:
+		value.SetBool(gconv.Bool(source))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(gconv.Int64(source))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(gconv.Uint64(source))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(gconv.Float64(source))
+	default:
+		value.Set(reflect.ValueOf(source))
+	}
+}
@@ -77,6 +77,10 @@ type Doc interface {
 	GetSparseVector() map[int32]float32
 	GetFields() map[string]any
 	GetScore() float32
+	GetString(name string) (string, bool)
+	GetInt64(name string) (int64, bool)
+	GetFloat64(name string) (float64, bool)
+	GetBool(name string) (bool, bool)
 }
 
 type Group interface {
@@ -111,3 +115,27 @@ type ResponseUsage interface {
 	GetReadUnits() int
 	GetWriteUnits() int
 }
+
+type Operation string
+
+//goland:noinspection GoUnusedConst
+const (
+	OperationCollectionCreate Operation = "collection.create"
+	OperationCollectionDesc   Operation = "collection.desc"
+	OperationCollectionList   Operation = "collection.list"
+	OperationCollectionStats  Operation = "collection.stats"
+	OperationCollectionDelete Operation = "collection.delete"
+	OperationPartitionCreate  Operation = "partition.create"
+	OperationPartitionDesc    Operation = "partition.desc"
+	OperationPartitionList    Operation = "partition.list"
+	OperationPartitionStats   Operation = "partition.stats"
+	OperationPartitionDelete  Operation = "partition.delete"
+	OperationDocsInsert       Operation = "docs.insert"
+	OperationDocsUpdate       Operation = "docs.update"
+	OperationDocsUpsert       Operation = "docs.upsert"
+	OperationDocsGet          Operation = "docs.get"
+	OperationDocsDrop         Operation = "docs.drop"
+	OperationDocsDropAll      Operation = "docs.drop_all"
+	OperationDocsQuery        Operation = "docs.query"
+	OperationDocsGroupQuery   Operation = "docs.group_query"
+)
@@ -1,10 +1,13 @@
 package dashvector
 
 import (
+	"encoding/json"
 	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/util/gconv"
 	"github.com/samber/lo"
+	"math"
+	"reflect"
 )
 
 func parseCollectionMeta(json *gjson.Json) CollectionMeta {
@@ -193,6 +196,66 @@ func (d *doc) GetScore() float32 {
 	return d.Score
 }
 
+func (d *doc) GetString(name string) (string, bool) {
+	value, ok := d.Fields[name].(string)
+	return value, ok
+}
+
+func (d *doc) GetInt64(name string) (int64, bool) {
+	return fieldInt64(d.Fields[name])
+}
+
+func (d *doc) GetFloat64(name string) (float64, bool) {
+	return fieldFloat64(d.Fields[name])
+}
+
+func (d *doc) GetBool(name string) (bool, bool) {
+	value, ok := d.Fields[name].(bool)
+	return value, ok
+}
+
+func fieldInt64(value any) (int64, bool) {
+	if number, ok := value.(json.Number); ok {
+		if i, err := number.Int64(); err == nil {
+			return i, true
+		}
+		f, err := number.Float64()
+		if err != nil || f != math.Trunc(f) {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f == math.Trunc(f) {
+			return int64(f), true
+		}
+	}
+	return 0, false
+}
+
+func fieldFloat64(value any) (float64, bool) {
+	if number, ok := value.(json.Number); ok {
+		f, err := number.Float64()
+		return f, err == nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
 func parseGroup(json *gjson.Json) Group {
 	return &group{
 		GroupId: json.Get("group_id").String(),
@@ -0,0 +1,223 @@
+package dashvector
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	usageUnitRead  = "read"
+	usageUnitWrite = "write"
+)
+
+var readOperations = map[Operation]bool{
+	OperationDocsGet:        true,
+	OperationDocsQuery:      true,
+	OperationDocsGroupQuery: true,
+}
+
+var writeOperations = map[Operation]bool{
+	OperationDocsInsert:  true,
+	OperationDocsUpdate:  true,
+	OperationDocsUpsert:  true,
+	OperationDocsDrop:    true,
+	OperationDocsDropAll: true,
+}
+
+type UsageKey struct {
+	Collection string
+	Partition  string
+}
+
+type Usage struct {
+	ReadUnits  int64
+	WriteUnits int64
+}
+
+func (u Usage) add(other Usage) Usage {
+	return Usage{ReadUnits: u.ReadUnits + other.ReadUnits, WriteUnits: u.WriteUnits + other.WriteUnits}
+}
+
+type UsageConfig func(*UsageMeter)
+
+// UsageWithReadBudget limits the read units consumed per window. Once the limit is
+// reached, read operations are rejected, or throttled with UsageWithThrottle, until the window ends.
+// A window of 0 makes the limit cumulative: it is never reset, and operations over it are always rejected.
+func UsageWithReadBudget(limit int64, window time.Duration) UsageConfig {
+	return func(meter *UsageMeter) {
+		meter.read = &usageBudget{unit: usageUnitRead, limit: limit, window: window}
+	}
+}
+
+// UsageWithWriteBudget limits the write units consumed per window, see UsageWithReadBudget.
+func UsageWithWriteBudget(limit int64, window time.Duration) UsageConfig {
+	return func(meter *UsageMeter) {
+		meter.write = &usageBudget{unit: usageUnitWrite, limit: limit, window: window}
+	}
+}
+
+// UsageWithThrottle makes operations over budget wait for the next window instead of failing.
+func UsageWithThrottle(throttle bool) UsageConfig {
+	return func(meter *UsageMeter) {
+		meter.throttle = throttle
+	}
+}
+
+// UsageMeter aggregates the ResponseUsage of doc operations per collection and partition,
+// and optionally enforces read and write unit budgets. Install it with ClientWithUsageMeter;
+// one meter may be shared by several clients.
+type UsageMeter struct {
+	mutex    sync.Mutex
+	usage    map[UsageKey]Usage
+	read     *usageBudget
+	write    *usageBudget
+	throttle bool
+}
+
+func NewUsageMeter(configs ...UsageConfig) *UsageMeter {
+	meter := &UsageMeter{usage: make(map[UsageKey]Usage)}
+	for _, cfg := range configs {
+		cfg(meter)
+	}
+	return meter
+}
+
+// Snapshot returns a copy of the usage recorded since creation or the last Reset.
+func (m *UsageMeter) Snapshot() map[UsageKey]Usage {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	snapshot := make(map[UsageKey]Usage, len(m.usage))
+	for key, usage := range m.usage {
+		snapshot[key] = usage
+	}
+	return snapshot
+}
+
+func (m *UsageMeter) Total() Usage {
+	return m.sum(func(UsageKey) bool { return true })
+}
+
+func (m *UsageMeter) CollectionUsage(collectionName string) Usage {
+	return m.sum(func(key UsageKey) bool { return key.Collection == collectionName })
+}
+
+func (m *UsageMeter) PartitionUsage(collectionName, partitionName string) Usage {
+	return m.sum(func(key UsageKey) bool {
+		return key.Collection == collectionName && key.Partition == partitionName
+	})
+}
+
+// Reset clears the counters and returns the usage recorded before. Budget windows are not affected.
+func (m *UsageMeter) Reset() map[UsageKey]Usage {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	snapshot := m.usage
+	m.usage = make(map[UsageKey]Usage)
+	return snapshot
+}
+
+func (m *UsageMeter) sum(match func(UsageKey) bool) Usage {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var total Usage
+	for key, usage := range m.usage {
+		if match(key) {
+			total = total.add(usage)
+		}
+	}
+	return total
+}
+
+func (m *UsageMeter) Intercept(ctx context.Context, invocation *Invocation, next Invoker) (Response, error) {
+	if err := m.acquire(ctx, invocation.Operation); err != nil {
+		return nil, err
+	}
+	response, err := next(ctx, invocation)
+	if usage, ok := response.(usageResponse); ok && usage.GetUsage() != nil {
+		m.record(UsageKey{Collection: invocation.Collection, Partition: invocation.Partition}, Usage{
+			ReadUnits:  int64(usage.GetUsage().GetReadUnits()),
+			WriteUnits: int64(usage.GetUsage().GetWriteUnits()),
+		})
+	}
+	return response, err
+}
+
+// acquire returns nil when the budget of the operation's unit is not exhausted,
+// waiting for the next window when throttling.
+func (m *UsageMeter) acquire(ctx context.Context, operation Operation) error {
+	var budget *usageBudget
+	switch {
+	case readOperations[operation]:
+		budget = m.read
+	case writeOperations[operation]:
+		budget = m.write
+	}
+	if budget == nil {
+		return nil
+	}
+	for {
+		m.mutex.Lock()
+		retryAfter, exhausted := budget.exhausted(time.Now())
+		m.mutex.Unlock()
+		if !exhausted {
+			return nil
+		}
+		if !m.throttle || budget.window <= 0 {
+			return &BudgetExceededError{Operation: operation, Unit: budget.unit,
+				Limit: budget.limit, Window: budget.window, RetryAfter: retryAfter}
+		}
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (m *UsageMeter) record(key UsageKey, usage Usage) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.usage[key] = m.usage[key].add(usage)
+	now := time.Now()
+	if m.read != nil {
+		m.read.consume(now, usage.ReadUnits)
+	}
+	if m.write != nil {
+		m.write.consume(now, usage.WriteUnits)
+	}
+}
+
+// usageBudget is a fixed window counter of consumed units, cumulative without a window.
+type usageBudget struct {
+	unit   string
+	limit  int64
+	window time.Duration
+	start  time.Time
+	used   int64
+}
+
+func (b *usageBudget) roll(now time.Time) {
+	if b.window > 0 && now.Sub(b.start) >= b.window {
+		b.start, b.used = now, 0
+	}
+}
+
+// exhausted reports whether the limit is reached, and how long until the window ends.
+func (b *usageBudget) exhausted(now time.Time) (time.Duration, bool) {
+	b.roll(now)
+	if b.used < b.limit {
+		return 0, false
+	}
+	if b.window <= 0 {
+		return 0, true
+	}
+	return b.start.Add(b.window).Sub(now), true
+}
+
+func (b *usageBudget) consume(now time.Time, units int64) {
+	b.roll(now)
+	b.used += units
+}
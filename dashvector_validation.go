@@ -2,9 +2,37 @@ package dashvector
 
 import (
 	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/util/gvalid"
+	"github.com/samber/lo"
+	"math"
 )
 
+func validateClientConfig(clusterEndpoint, apiKey string, config *clientConfig) error {
+	if clusterEndpoint == "" {
+		return gerror.NewCode(gcode.CodeInvalidParameter, "clusterEndpoint is required")
+	}
+	if apiKey == "" {
+		return gerror.NewCode(gcode.CodeInvalidParameter, "apiKey is required")
+	}
+	if config.Scheme != schemeHttp && config.Scheme != schemeHttps {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, "scheme is unsupported: %s", config.Scheme)
+	}
+	return nil
+}
+
+func validateLocalClientConfig(config *clientConfig) error {
+	if config.HttpClient != nil {
+		return gerror.NewCode(gcode.CodeInvalidParameter, "httpClient is unsupported by local client")
+	}
+	if config.Scheme != schemeHttps {
+		return gerror.NewCode(gcode.CodeInvalidParameter, "scheme is unsupported by local client")
+	}
+	return nil
+}
+
 func validateCollectionName(ctx context.Context, collectionName string) error {
 	return gvalid.New().Rules("required").
 		Messages("collectionName is required").
@@ -17,18 +45,95 @@ func validatePartitionName(ctx context.Context, partitionName string) error {
 		Data(partitionName).Run(ctx)
 }
 
-func validInsertDocument(document *doc) bool {
+func validateInsertDocument(document *doc) string {
 	if len(document.Vector) > 0 {
-		return true
+		return ""
 	}
 	for name, vec := range document.Vectors {
 		if name != "" && len(vec) > 0 {
-			return true
+			return ""
+		}
+	}
+	return "vector is required"
+}
+
+func validateUpdateDocument(document *doc) string {
+	if document.Id != "" {
+		return ""
+	}
+	return "id is required"
+}
+
+func validateUpsertDocument(document *doc) string {
+	if validateUpdateDocument(document) == "" || validateInsertDocument(document) == "" {
+		return ""
+	}
+	return "id or vector is required"
+}
+
+func strictDocumentValidator(validate func(*doc) string) func(*doc) string {
+	ids := make(map[string]bool)
+	return func(document *doc) string {
+		if reason := validate(document); reason != "" {
+			return reason
+		}
+		if reason := validateDocumentValues(document); reason != "" {
+			return reason
+		}
+		if document.Id != "" {
+			if ids[document.Id] {
+				return fmt.Sprintf("duplicate id %s in batch", document.Id)
+			}
+			ids[document.Id] = true
+		}
+		return ""
+	}
+}
+
+func validateDocumentValues(document *doc) string {
+	if reason := validateVectorValues("vector", document.Vector); reason != "" {
+		return reason
+	}
+	for _, name := range lo.Keys(document.Vectors) {
+		if name == "" {
+			return "vector name is empty"
+		}
+		if len(document.Vectors[name]) == 0 {
+			return fmt.Sprintf("vector %s is empty", name)
+		}
+		if reason := validateVectorValues("vector "+name, document.Vectors[name]); reason != "" {
+			return reason
+		}
+	}
+	for key, value := range document.SparseVector {
+		if invalidFloat(float64(value)) {
+			return fmt.Sprintf("sparse_vector[%d] is %v", key, value)
+		}
+	}
+	for name, value := range document.Fields {
+		switch v := value.(type) {
+		case float32:
+			if invalidFloat(float64(v)) {
+				return fmt.Sprintf("field %s is %v", name, v)
+			}
+		case float64:
+			if invalidFloat(v) {
+				return fmt.Sprintf("field %s is %v", name, v)
+			}
+		}
+	}
+	return ""
+}
+
+func validateVectorValues(name string, vector []float32) string {
+	for i, value := range vector {
+		if invalidFloat(float64(value)) {
+			return fmt.Sprintf("%s[%d] is %v", name, i, value)
 		}
 	}
-	return false
+	return ""
 }
 
-func validUpdateDocument(document *doc) bool {
-	return document.Id != ""
+func invalidFloat(value float64) bool {
+	return math.IsNaN(value) || math.IsInf(value, 0)
 }
@@ -0,0 +1,71 @@
+package dashvector
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultWaitInterval    = time.Millisecond * 10
+	defaultWaitMaxInterval = time.Second
+	defaultWaitMultiplier  = 2.0
+)
+
+func newWaitPolicy(configs ...WaitConfig) *waitPolicy {
+	policy := &waitPolicy{
+		Interval:    defaultWaitInterval,
+		MaxInterval: defaultWaitMaxInterval,
+		Multiplier:  defaultWaitMultiplier,
+	}
+	for _, cfg := range configs {
+		cfg(policy)
+	}
+	return policy
+}
+
+type waitPolicy struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+	Timeout     time.Duration
+}
+
+func (p *waitPolicy) poll(ctx context.Context, check func(context.Context) (bool, error)) error {
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	interval := p.Interval
+	for {
+		if done, err := check(ctx); done || err != nil {
+			return err
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		interval = time.Duration(float64(interval) * p.Multiplier)
+		if p.MaxInterval > 0 && interval > p.MaxInterval {
+			interval = p.MaxInterval
+		}
+	}
+}
+
+type FailedStatusError struct {
+	Collection string
+	Partition  string
+	Status     Status
+}
+
+func (e *FailedStatusError) Error() string {
+	if e.Partition != "" {
+		return fmt.Sprintf("dashvector partition %s/%s entered status %s",
+			e.Collection, e.Partition, e.Status)
+	}
+	return fmt.Sprintf("dashvector collection %s entered status %s", e.Collection, e.Status)
+}
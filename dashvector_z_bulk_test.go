@@ -0,0 +1,231 @@
+package dashvector_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Bulk_Writer(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			batches      []int
+			batchesMutex sync.Mutex
+			written      int32
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bytes, _ := io.ReadAll(r.Body)
+			batchesMutex.Lock()
+			batches = append(batches, len(gjson.New(bytes).Get("docs").Array()))
+			batchesMutex.Unlock()
+			var output []string
+			for _, doc := range gjson.New(bytes).Get("docs").Array() {
+				id := gjson.New(doc).Get("id").String()
+				code := 0
+				if id == "bad" {
+					code = -2027
+				}
+				atomic.AddInt32(&written, 1)
+				output = append(output, fmt.Sprintf(`{"id":"%s","code":%d,"message":"","doc_op":"upsert"}`, id, code))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[` + gstr.Join(output, ",") + `]}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+
+		var failures []dashvector.BulkWriteFailure
+		var failuresMutex sync.Mutex
+		writer := c.GetCollection("c").BulkWriter(
+			dashvector.BulkWriterWithBatchSize(10),
+			dashvector.BulkWriterWithFlushInterval(time.Hour),
+			dashvector.BulkWriterWithMaxPending(2),
+			dashvector.BulkWriterWithFailureHandler(func(failure dashvector.BulkWriteFailure) {
+				failuresMutex.Lock()
+				defer failuresMutex.Unlock()
+				failures = append(failures, failure)
+			}))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 5; j++ {
+					t.AssertNil(writer.Write(ctx, dashvector.WithId(fmt.Sprintf("%d-%d", i, j)),
+						dashvector.WithVector(0.1)))
+				}
+			}(i)
+		}
+		wg.Wait()
+		t.AssertNil(writer.Flush(ctx))
+		t.Assert(batches, []int{10, 10})
+		t.AssertNil(writer.Write(ctx, dashvector.WithId("bad"), dashvector.WithVector(0.1)))
+		t.AssertNE(writer.Write(ctx), nil)
+		t.AssertNil(writer.Flush(ctx))
+		t.Assert(atomic.LoadInt32(&written), 21)
+		t.Assert(batches, []int{10, 10, 1})
+		t.Assert(len(failures), 1)
+		t.Assert(failures[0].Id, "bad")
+		t.Assert(failures[0].Code, -2027)
+
+		t.AssertNil(writer.Write(ctx, dashvector.WithId("last"), dashvector.WithVector(0.1)))
+		t.AssertNil(writer.Close(ctx))
+		t.Assert(atomic.LoadInt32(&written), 22)
+		t.Assert(batches, []int{10, 10, 1, 1})
+		t.Assert(writer.Write(ctx, dashvector.WithId("closed")), dashvector.ErrBulkWriterClosed)
+	})
+}
+
+func Test_Bulk_Writer_Close(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":-2027,"message":"duplicate","request_id":"id"}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		var failures int32
+		writer := c.GetCollection("c").BulkWriter(
+			dashvector.BulkWriterWithFailureHandler(func(dashvector.BulkWriteFailure) {
+				atomic.AddInt32(&failures, 1)
+			}))
+		t.AssertNil(writer.Write(ctx, dashvector.WithId("1"), dashvector.WithVector(0.1)))
+		err = writer.Close(ctx)
+		t.Assert(errors.Is(err, dashvector.ErrDuplicateKey), true)
+		t.Assert(atomic.LoadInt32(&failures), 1)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.ReadAll(r.Body)
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		failures := make(chan dashvector.BulkWriteFailure, 1)
+		writer := c.GetCollection("c").BulkWriter(
+			dashvector.BulkWriterWithFailureHandler(func(failure dashvector.BulkWriteFailure) {
+				failures <- failure
+			}))
+		t.AssertNil(writer.Write(ctx, dashvector.WithId("1"), dashvector.WithVector(0.1)))
+		timeout, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		t.Assert(errors.Is(writer.Close(timeout), context.DeadlineExceeded), true)
+		select {
+		case failure := <-failures:
+			t.Assert(failure.Id, "1")
+			t.Assert(errors.Is(failure.Err, context.DeadlineExceeded), true)
+		case <-time.After(time.Second):
+			t.Error("final flush was not cancelled")
+		}
+	})
+}
+
+func Test_Bulk_Writer_Background(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var busy int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bytes, _ := io.ReadAll(r.Body)
+			var ids, output []string
+			for _, doc := range gjson.New(bytes).Get("docs").Array() {
+				ids = append(ids, gjson.New(doc).Get("id").String())
+				output = append(output, fmt.Sprintf(`{"id":"%s","code":0,"message":"","doc_op":"upsert"}`, ids[len(ids)-1]))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if ids[0] == "busy" && atomic.AddInt32(&busy, 1) == 1 {
+				_, _ = w.Write([]byte(`{"code":-2034,"message":"busy","request_id":"id"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[` + gstr.Join(output, ",") + `]}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server, dashvector.ClientWithChunkSize(2))
+		t.AssertNil(err)
+		failures := make(chan dashvector.BulkWriteFailure, 4)
+		writer := c.GetCollection("c").BulkWriter(
+			dashvector.BulkWriterWithBatchSize(4),
+			dashvector.BulkWriterWithFlushInterval(time.Hour),
+			dashvector.BulkWriterWithFailureHandler(func(failure dashvector.BulkWriteFailure) {
+				failures <- failure
+			}))
+		for _, id := range []string{"0", "1", "busy", "3"} {
+			t.AssertNil(writer.Write(ctx, dashvector.WithId(id), dashvector.WithVector(0.1)))
+		}
+		err = writer.Flush(ctx)
+		t.Assert(errors.Is(err, dashvector.ErrRateLimitExceeded), true)
+		t.Assert(len(failures), 2)
+		for _, id := range []string{"busy", "3"} {
+			failure := <-failures
+			t.Assert(failure.Id, id)
+			t.Assert(failure.Code, dashvector.CodeExceedRateLimit)
+			t.Assert(failure.Message, "busy")
+		}
+		t.AssertNil(writer.Flush(ctx))
+		t.AssertNil(writer.Close(ctx))
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":-2034,"message":"busy","request_id":"id"}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		writer := c.GetCollection("c").BulkWriter(dashvector.BulkWriterWithBatchSize(1))
+		t.AssertNil(writer.Write(ctx, dashvector.WithId("1"), dashvector.WithVector(0.1)))
+		t.Assert(errors.Is(writer.Flush(ctx), dashvector.ErrRateLimitExceeded), true)
+		t.AssertNil(writer.Flush(ctx))
+		t.AssertNil(writer.Write(ctx, dashvector.WithId("2"), dashvector.WithVector(0.1)))
+		t.Assert(errors.Is(writer.Close(ctx), dashvector.ErrRateLimitExceeded), true)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.ReadAll(r.Body)
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		failures := make(chan dashvector.BulkWriteFailure, 1)
+		writer := c.GetCollection("c").BulkWriter(
+			dashvector.BulkWriterWithBatchSize(1),
+			dashvector.BulkWriterWithFailureHandler(func(failure dashvector.BulkWriteFailure) {
+				failures <- failure
+			}))
+		t.AssertNil(writer.Write(ctx, dashvector.WithId("1"), dashvector.WithVector(0.1)))
+		timeout, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		t.Assert(errors.Is(writer.Close(timeout), context.DeadlineExceeded), true)
+		select {
+		case failure := <-failures:
+			t.Assert(failure.Id, "1")
+			t.Assert(errors.Is(failure.Err, context.Canceled), true)
+		case <-time.After(time.Second):
+			t.Error("background flush was not cancelled")
+		}
+	})
+}
@@ -0,0 +1,205 @@
+package dashvector_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Chunk_Write(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var count int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&count, 1)
+			bytes, _ := io.ReadAll(r.Body)
+			body := gjson.New(bytes)
+			var ids []string
+			if r.Method == http.MethodDelete {
+				ids = body.Get("ids").Strings()
+			} else {
+				for _, doc := range body.Get("docs").Array() {
+					ids = append(ids, gjson.New(doc).Get("id").String())
+				}
+			}
+			var output []string
+			for _, id := range ids {
+				output = append(output, fmt.Sprintf(`{"id":"%s","code":0,"message":"","doc_op":"insert"}`, id))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"code":0,"message":"","request_id":"id",`+
+				`"output":[%s],"usage":{"write_units":%d}}`, gstr.Join(output, ","), len(ids))))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server,
+			dashvector.ClientWithChunkSize(2),
+			dashvector.ClientWithChunkConcurrency(2))
+		t.AssertNil(err)
+		partition := c.GetCollection("c")
+
+		var configs []dashvector.DocumentsConfig
+		var ids []string
+		for i := 0; i < 5; i++ {
+			ids = append(ids, fmt.Sprint(i))
+			configs = append(configs, dashvector.WithDocument(
+				dashvector.WithId(fmt.Sprint(i)), dashvector.WithVector(0.1)))
+		}
+		insertResponse, err := partition.Insert(ctx, configs...)
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&count), 3)
+		t.Assert(insertResponse.GetCode(), 0)
+		t.Assert(len(insertResponse.GetOutput()), 5)
+		for i, result := range insertResponse.GetOutput() {
+			t.Assert(result.GetId(), fmt.Sprint(i))
+		}
+		t.Assert(insertResponse.GetUsage().GetWriteUnits(), 5)
+
+		atomic.StoreInt32(&count, 0)
+		dropResponse, err := partition.Drop(ctx, ids...)
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&count), 3)
+		t.Assert(len(dropResponse.GetOutput()), 5)
+		t.Assert(dropResponse.GetUsage().GetWriteUnits(), 5)
+	})
+}
+
+func Test_Chunk_PartialWrite(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var attempts sync.Map
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bytes, _ := io.ReadAll(r.Body)
+			var output []string
+			for _, doc := range gjson.New(bytes).Get("docs").Array() {
+				id := gjson.New(doc).Get("id").String()
+				count, _ := attempts.LoadOrStore(id, new(int32))
+				code := 0
+				if id == "bad" || (id == "flaky" && atomic.AddInt32(count.(*int32), 1) == 1) {
+					code = -2034
+				}
+				if id == "dup" {
+					atomic.AddInt32(count.(*int32), 1)
+					code = -2027
+				}
+				output = append(output, fmt.Sprintf(`{"id":"%s","code":%d,"message":"failed","doc_op":"upsert"}`, id, code))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"code":0,"message":"","request_id":"id",`+
+				`"output":[%s],"usage":{"write_units":%d}}`, gstr.Join(output, ","), len(output))))
+		}))
+		defer server.Close()
+
+		configs := []dashvector.DocumentsConfig{
+			dashvector.WithDocument(dashvector.WithId("good"), dashvector.WithVector(0.1)),
+			dashvector.WithDocument(dashvector.WithId("flaky"), dashvector.WithVector(0.1)),
+			dashvector.WithDocument(dashvector.WithId("bad"), dashvector.WithVector(0.1)),
+		}
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		upsertResponse, err := c.GetCollection("c").Upsert(ctx, configs...)
+		t.AssertNil(err)
+		t.Assert(len(upsertResponse.Succeeded()), 1)
+		t.Assert(len(upsertResponse.Failed()), 2)
+		var partialErr *dashvector.PartialWriteError
+		t.Assert(errors.As(upsertResponse.Err(), &partialErr), true)
+		t.Assert(partialErr.Total, 3)
+		t.Assert(partialErr.Failures[0].GetId(), "flaky")
+		t.Assert(partialErr.Failures[1].GetId(), "bad")
+
+		attempts = sync.Map{}
+		c, err = newServerClient(server,
+			dashvector.ClientWithFailedDocsRetry(2),
+			dashvector.ClientWithRetry(dashvector.RetryWithBaseDelay(time.Millisecond)))
+		t.AssertNil(err)
+		upsertResponse, err = c.GetCollection("c").Upsert(ctx, configs...)
+		t.AssertNil(err)
+		t.Assert(len(upsertResponse.Succeeded()), 2)
+		t.Assert(len(upsertResponse.Failed()), 1)
+		t.Assert(upsertResponse.Failed()[0].GetId(), "bad")
+		t.Assert(upsertResponse.GetUsage().GetWriteUnits(), 6)
+
+		attempts = sync.Map{}
+		insertResponse, err := c.GetCollection("c").Insert(ctx,
+			dashvector.WithDocument(dashvector.WithId("dup"), dashvector.WithVector(0.1)),
+			dashvector.WithDocument(dashvector.WithId("flaky"), dashvector.WithVector(0.1)))
+		t.AssertNil(err)
+		t.Assert(len(insertResponse.Succeeded()), 1)
+		t.Assert(insertResponse.Failed()[0].GetId(), "dup")
+		t.Assert(insertResponse.Failed()[0].GetCode(), dashvector.CodeDuplicateKey)
+		dupAttempts, _ := attempts.Load("dup")
+		t.Assert(atomic.LoadInt32(dupAttempts.(*int32)), 1)
+		t.Assert(insertResponse.GetUsage().GetWriteUnits(), 3)
+	})
+}
+
+func Test_Chunk_FailedChunk(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var busy int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bytes, _ := io.ReadAll(r.Body)
+			var ids, output []string
+			for _, doc := range gjson.New(bytes).Get("docs").Array() {
+				ids = append(ids, gjson.New(doc).Get("id").String())
+				output = append(output, fmt.Sprintf(`{"id":"%s","code":0,"message":"","doc_op":"insert"}`, ids[len(ids)-1]))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if ids[0] == "busy" && atomic.AddInt32(&busy, 1) == 1 {
+				_, _ = w.Write([]byte(`{"code":-2034,"message":"busy","request_id":"id"}`))
+				return
+			}
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"code":0,"message":"","request_id":"id",`+
+				`"output":[%s],"usage":{"write_units":%d}}`, gstr.Join(output, ","), len(output))))
+		}))
+		defer server.Close()
+
+		configs := []dashvector.DocumentsConfig{
+			dashvector.WithDocument(dashvector.WithId("0"), dashvector.WithVector(0.1)),
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(0.1)),
+			dashvector.WithDocument(dashvector.WithId("busy"), dashvector.WithVector(0.1)),
+			dashvector.WithDocument(dashvector.WithId("3"), dashvector.WithVector(0.1)),
+		}
+
+		c, err := newServerClient(server, dashvector.ClientWithChunkSize(2))
+		t.AssertNil(err)
+		insertResponse, err := c.GetCollection("c").Insert(ctx, configs...)
+		t.AssertNil(err)
+		t.Assert(insertResponse.GetCode(), dashvector.CodeExceedRateLimit)
+		t.Assert(len(insertResponse.GetOutput()), 4)
+		t.Assert(len(insertResponse.Succeeded()), 2)
+		failed := insertResponse.Failed()
+		t.Assert(len(failed), 2)
+		t.Assert(failed[0].GetId(), "busy")
+		t.Assert(failed[1].GetId(), "3")
+		t.Assert(failed[1].GetCode(), dashvector.CodeExceedRateLimit)
+		t.Assert(failed[1].GetMessage(), "busy")
+		t.Assert(failed[1].GetDocOp(), dashvector.DocOpInsert)
+		var partialErr *dashvector.PartialWriteError
+		t.Assert(errors.As(insertResponse.Err(), &partialErr), true)
+		t.Assert(partialErr.Total, 4)
+		t.Assert(len(partialErr.Failures), 2)
+
+		atomic.StoreInt32(&busy, 0)
+		c, err = newServerClient(server,
+			dashvector.ClientWithChunkSize(2),
+			dashvector.ClientWithFailedDocsRetry(1),
+			dashvector.ClientWithRetry(dashvector.RetryWithBaseDelay(time.Millisecond)))
+		t.AssertNil(err)
+		insertResponse, err = c.GetCollection("c").Insert(ctx, configs...)
+		t.AssertNil(err)
+		t.Assert(insertResponse.GetCode(), dashvector.CodeSuccess)
+		t.Assert(len(insertResponse.Succeeded()), 4)
+		t.AssertNil(insertResponse.Err())
+		t.Assert(atomic.LoadInt32(&busy), 2)
+		t.Assert(insertResponse.GetUsage().GetWriteUnits(), 4)
+	})
+}
@@ -0,0 +1,237 @@
+package dashvector_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/test/gtest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Client_WithOptions(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Assert(r.URL.Path, "/v1/collections")
+			t.Assert(r.Header.Get("dashvector-auth-token"), "apiKey")
+			t.Assert(r.Header.Get("User-Agent"), "custom-agent")
+			t.Assert(r.Header.Get("X-Tenant"), "tenant")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":["c1","c2"]}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server,
+			dashvector.ClientWithTimeout(time.Second),
+			dashvector.ClientWithUserAgent("custom-agent"),
+			dashvector.ClientWithHeader("X-Tenant", "tenant"),
+		)
+		t.AssertNil(err)
+		listResponse, err := c.List(ctx)
+		t.AssertNil(err)
+		t.Assert(listResponse.GetCode(), 0)
+		t.Assert(listResponse.GetRequestId(), "id")
+		t.Assert(listResponse.GetOutput(), []string{"c1", "c2"})
+	})
+}
+
+func Test_Client_WithOptions_Invalid(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := dashvector.NewClientWithOptions("", "apiKey")
+		t.AssertNE(err, nil)
+		_, err = dashvector.NewClientWithOptions("endpoint", "")
+		t.AssertNE(err, nil)
+		_, err = dashvector.NewClientWithOptions("endpoint", "apiKey",
+			dashvector.ClientWithScheme("ftp"))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Client_CodeError(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":-2021,"message":"collection not exist","request_id":"id"}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		descResponse, err := c.Desc(ctx, "missing")
+		t.AssertNil(err)
+		t.Assert(descResponse.GetCode(), dashvector.CodeInexistentCollection)
+
+		c, err = newServerClient(server, dashvector.ClientWithCodeError(true))
+		t.AssertNil(err)
+		descResponse, err = c.Desc(ctx, "missing")
+		t.Assert(descResponse.GetCode(), dashvector.CodeInexistentCollection)
+		t.Assert(errors.Is(err, dashvector.ErrCollectionNotFound), true)
+		t.Assert(errors.Is(err, dashvector.ErrCollectionExists), false)
+		var dvErr *dashvector.Error
+		t.Assert(errors.As(err, &dvErr), true)
+		t.Assert(dvErr.Message, "collection not exist")
+		t.Assert(dvErr.RequestId, "id")
+		t.Assert(dvErr.StatusCode, http.StatusNotFound)
+		t.Assert(dvErr.Operation, dashvector.OperationCollectionDesc)
+	})
+}
+
+func Test_Client_ResponseError(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/collections/html":
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte("<html>" + strings.Repeat("x", 1024) + "</html>"))
+			case "/v1/collections/empty":
+				w.Header().Set("Content-Type", "application/json")
+			}
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+
+		_, err = c.Desc(ctx, "html")
+		var respErr *dashvector.ResponseError
+		t.Assert(errors.As(err, &respErr), true)
+		t.Assert(respErr.StatusCode, http.StatusBadGateway)
+		t.Assert(respErr.ContentType, "text/html")
+		t.Assert(respErr.Method, http.MethodGet)
+		t.Assert(respErr.Endpoint, server.URL+"/v1/collections/html")
+		t.Assert(strings.HasSuffix(respErr.Body, "...(truncated)"), true)
+		t.Assert(respErr.Operation, dashvector.OperationCollectionDesc)
+
+		_, err = c.Desc(ctx, "empty")
+		t.Assert(errors.As(err, &respErr), true)
+		t.Assert(respErr.StatusCode, http.StatusOK)
+		t.Assert(respErr.Body, "")
+	})
+}
+
+func Test_Client_Retry(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var count int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch atomic.AddInt32(&count, 1) % 3 {
+			case 1:
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"code":-999,"message":"unavailable","request_id":"id"}`))
+			case 2:
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"code":-2034,"message":"rate limit","request_id":"id"}`))
+			default:
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[]}`))
+			}
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server, dashvector.ClientWithRetry(
+			dashvector.RetryWithMaxAttempts(3),
+			dashvector.RetryWithBaseDelay(time.Millisecond),
+			dashvector.RetryWithMaxDelay(time.Millisecond*5),
+			dashvector.RetryWithJitter(0.5),
+		))
+		t.AssertNil(err)
+		partition := c.GetCollection("retry")
+
+		upsertResponse, err := partition.Upsert(ctx, dashvector.WithDocument(
+			dashvector.WithId("1"), dashvector.WithVector(0.1)))
+		t.AssertNil(err)
+		t.Assert(upsertResponse.GetCode(), 0)
+		t.Assert(atomic.LoadInt32(&count), 3)
+
+		insertResponse, err := partition.Insert(ctx, dashvector.WithDocument(
+			dashvector.WithId("1"), dashvector.WithVector(0.1)))
+		t.AssertNil(err)
+		t.Assert(insertResponse.GetCode(), -999)
+		t.Assert(atomic.LoadInt32(&count), 4)
+
+		atomic.StoreInt32(&count, 0)
+		c, err = newServerClient(server, dashvector.ClientWithRetry(
+			dashvector.RetryWithBaseDelay(time.Millisecond),
+			dashvector.RetryWithNonIdempotent(true),
+		))
+		t.AssertNil(err)
+		insertResponse, err = c.GetCollection("retry").Insert(ctx, dashvector.WithDocument(
+			dashvector.WithId("1"), dashvector.WithVector(0.1)))
+		t.AssertNil(err)
+		t.Assert(insertResponse.GetCode(), 0)
+		t.Assert(atomic.LoadInt32(&count), 3)
+	})
+}
+
+func Test_Client_Retry_Codes(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var count int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if atomic.AddInt32(&count, 1)%2 == 1 {
+				_, _ = w.Write([]byte(`{"code":-2034,"message":"rate limit","request_id":"id"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"code":-2999,"message":"busy","request_id":"id"}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server, dashvector.ClientWithRetry(
+			dashvector.RetryWithBaseDelay(time.Millisecond),
+			dashvector.RetryWithCodes(),
+		))
+		t.AssertNil(err)
+		listResponse, err := c.List(ctx)
+		t.AssertNil(err)
+		t.Assert(listResponse.GetCode(), dashvector.CodeExceedRateLimit)
+		t.Assert(atomic.LoadInt32(&count), 1)
+
+		atomic.StoreInt32(&count, 0)
+		c, err = newServerClient(server, dashvector.ClientWithRetry(
+			dashvector.RetryWithBaseDelay(time.Millisecond),
+			dashvector.RetryWithCodes(-2999),
+		))
+		t.AssertNil(err)
+		listResponse, err = c.List(ctx)
+		t.AssertNil(err)
+		t.Assert(listResponse.GetCode(), dashvector.CodeExceedRateLimit)
+		t.Assert(atomic.LoadInt32(&count), 1)
+		listResponse, err = c.List(ctx)
+		t.AssertNil(err)
+		t.Assert(listResponse.GetCode(), dashvector.CodeExceedRateLimit)
+		t.Assert(atomic.LoadInt32(&count), 3)
+	})
+}
+
+func Test_Client_Retry_CertificateError(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[]}`))
+		}))
+		defer server.Close()
+
+		capture := &headerCapture{transport: &http.Transport{}}
+		c, err := dashvector.NewClientWithOptions(strings.TrimPrefix(server.URL, "https://"), "apiKey",
+			dashvector.ClientWithHttpClient(&http.Client{Transport: capture}),
+			dashvector.ClientWithRetry(dashvector.RetryWithBaseDelay(time.Millisecond)))
+		t.AssertNil(err)
+		_, err = c.List(ctx)
+		var certErr *tls.CertificateVerificationError
+		t.Assert(errors.As(err, &certErr), true)
+		t.Assert(len(capture.headers), 1)
+	})
+}
+
+func newServerClient(server *httptest.Server, configs ...dashvector.ClientConfig) (dashvector.Client, error) {
+	return dashvector.NewClientWithOptions(strings.TrimPrefix(server.URL, "http://"), "apiKey",
+		append([]dashvector.ClientConfig{
+			dashvector.ClientWithScheme("http"),
+			dashvector.ClientWithHttpClient(server.Client()),
+		}, configs...)...)
+}
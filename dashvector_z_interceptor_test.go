@@ -0,0 +1,137 @@
+package dashvector_test
+
+import (
+	"context"
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/CharLemAznable/dashvector-sdk-go/dashvectortest"
+	"github.com/gogf/gf/v2/test/gtest"
+	"net/http"
+	"testing"
+)
+
+type headerCapture struct {
+	transport http.RoundTripper
+	headers   []http.Header
+}
+
+func (c *headerCapture) RoundTrip(request *http.Request) (*http.Response, error) {
+	c.headers = append(c.headers, request.Header.Clone())
+	return c.transport.RoundTrip(request)
+}
+
+func Test_Interceptors(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var trace []string
+		var invocations []dashvector.Invocation
+		tracing := func(name string) dashvector.Interceptor {
+			return dashvector.InterceptorFunc(func(ctx context.Context, invocation *dashvector.Invocation,
+				next dashvector.Invoker) (dashvector.Response, error) {
+				trace = append(trace, name+">")
+				response, err := next(ctx, invocation)
+				trace = append(trace, "<"+name)
+				return response, err
+			})
+		}
+		auditing := dashvector.InterceptorFunc(func(ctx context.Context, invocation *dashvector.Invocation,
+			next dashvector.Invoker) (dashvector.Response, error) {
+			invocation.Header = map[string]string{"X-Tenant": "t1"}
+			response, err := next(ctx, invocation)
+			invocations = append(invocations, *invocation)
+			if response != nil {
+				t.Assert(response.GetCode(), dashvector.CodeSuccess)
+			}
+			return response, err
+		})
+
+		server := dashvectortest.NewServer()
+		defer server.Close()
+		capture := &headerCapture{transport: server.Client().Transport}
+		local, err := server.NewClient(
+			dashvector.ClientWithHttpClient(&http.Client{Transport: capture}),
+			dashvector.ClientWithInterceptors(tracing("a"), tracing("b")),
+			dashvector.ClientWithInterceptors(auditing))
+		t.AssertNil(err)
+
+		_, err = local.Create(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(err)
+		t.Assert(trace, []string{"a>", "b>", "<b", "<a"})
+		collection := local.GetCollection("c")
+		_, err = collection.Create(ctx, "p")
+		t.AssertNil(err)
+		_, err = collection.GetPartition("p").Upsert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(1, 1)))
+		t.AssertNil(err)
+		queryResponse, err := collection.GetPartition("p").Query(ctx, dashvector.QueryWithId("1"))
+		t.AssertNil(err)
+		t.Assert(len(queryResponse.GetOutput()), 1)
+		_, err = local.List(ctx)
+		t.AssertNil(err)
+
+		t.Assert(len(invocations), 5)
+		t.Assert(invocations[0].Operation, dashvector.OperationCollectionCreate)
+		t.Assert(invocations[0].Collection, "c")
+		t.AssertNE(invocations[0].Request, nil)
+		t.Assert(invocations[1].Operation, dashvector.OperationPartitionCreate)
+		t.Assert(invocations[1].Collection, "c")
+		t.Assert(invocations[1].Partition, "p")
+		t.Assert(invocations[2].Operation, dashvector.OperationDocsUpsert)
+		t.Assert(invocations[2].Partition, "p")
+		t.Assert(invocations[3].Operation, dashvector.OperationDocsQuery)
+		t.Assert(invocations[3].Method, http.MethodPost)
+		t.Assert(invocations[3].Url, "/collections/c/query")
+		t.Assert(invocations[4].Operation, dashvector.OperationCollectionList)
+		t.Assert(invocations[4].Collection, "")
+		t.Assert(invocations[4].Request, nil)
+
+		t.Assert(len(capture.headers), 5)
+		for _, header := range capture.headers {
+			t.Assert(header.Get("X-Tenant"), "t1")
+		}
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		errDenied := errors.New("denied")
+		local, err := dashvector.NewLocalClient(dashvector.ClientWithInterceptors(
+			dashvector.InterceptorFunc(func(ctx context.Context, invocation *dashvector.Invocation,
+				next dashvector.Invoker) (dashvector.Response, error) {
+				if invocation.Operation == dashvector.OperationCollectionDelete {
+					return nil, errDenied
+				}
+				return next(ctx, invocation)
+			})))
+		t.AssertNil(err)
+		_, err = local.Create(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(err)
+		response, err := local.Delete(ctx, "c")
+		t.AssertNil(response)
+		t.Assert(errors.Is(err, errDenied), true)
+		descResponse, err := local.Desc(ctx, "c")
+		t.AssertNil(err)
+		t.Assert(descResponse.GetOutput().GetName(), "c")
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		var synthetic dashvector.Response
+		local, err := dashvector.NewLocalClient(dashvector.ClientWithInterceptors(
+			dashvector.InterceptorFunc(func(ctx context.Context, invocation *dashvector.Invocation,
+				next dashvector.Invoker) (dashvector.Response, error) {
+				switch invocation.Operation {
+				case dashvector.OperationCollectionCreate:
+					return nil, nil
+				case dashvector.OperationCollectionStats:
+					return synthetic, nil
+				}
+				return next(ctx, invocation)
+			})))
+		t.AssertNil(err)
+		response, err := local.CreateServing(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(response)
+		t.Assert(err.Error(), "dashvector collection.create: interceptor returned unexpected response <nil>")
+
+		synthetic, err = local.List(ctx)
+		t.AssertNil(err)
+		_, err = local.WaitIndexed(ctx, "c", 1)
+		t.AssertNE(err, nil)
+	})
+}
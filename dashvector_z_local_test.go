@@ -0,0 +1,68 @@
+package dashvector_test
+
+import (
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/CharLemAznable/dashvector-sdk-go/filter"
+	"github.com/gogf/gf/v2/test/gtest"
+	"net/http"
+	"testing"
+)
+
+func Test_Local_Client(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		local, err := dashvector.NewLocalClient(dashvector.ClientWithCodeError(true))
+		t.AssertNil(err)
+		_, err = local.CreateServing(ctx, "local",
+			dashvector.WithDimension(2),
+			dashvector.WithMetric(dashvector.MetricDotproduct),
+			dashvector.WithFieldSchema("name", dashvector.FieldTypeString))
+		t.AssertNil(err)
+		collection := local.GetCollection("local")
+		_, err = collection.CreateServing(ctx, "p")
+		t.AssertNil(err)
+		partition := collection.GetPartition("p")
+
+		writeResponse, err := partition.Upsert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(1, 0),
+				dashvector.WithField("name", "a")),
+			dashvector.WithDocument(dashvector.WithId("2"), dashvector.WithVector(2, 2),
+				dashvector.WithField("name", "b")))
+		t.AssertNil(err)
+		t.AssertNil(writeResponse.Err())
+
+		queryResponse, err := partition.Query(ctx, dashvector.QueryWithVector(1, 1))
+		t.AssertNil(err)
+		t.Assert(len(queryResponse.GetOutput()), 2)
+		t.Assert(queryResponse.GetOutput()[0].GetId(), "2")
+		t.Assert(queryResponse.GetOutput()[0].GetScore(), 4)
+
+		queryResponse, err = partition.Query(ctx, dashvector.QueryWithVector(1, 1),
+			dashvector.QueryWithFilterExpr(filter.Eq("name", "a")))
+		t.AssertNil(err)
+		t.Assert(len(queryResponse.GetOutput()), 1)
+		t.Assert(queryResponse.GetOutput()[0].GetId(), "1")
+
+		statsResponse, err := collection.Stats(ctx, "p")
+		t.AssertNil(err)
+		t.Assert(statsResponse.GetOutput().GetTotalDocCount(), 2)
+
+		getResponse, err := collection.Get(ctx, "1")
+		t.AssertNil(err)
+		t.Assert(len(getResponse.GetOutput()), 0)
+
+		_, err = local.Desc(ctx, "missing")
+		t.Assert(errors.Is(err, dashvector.ErrCollectionNotFound), true)
+
+		other, err := dashvector.NewLocalClient()
+		t.AssertNil(err)
+		listResponse, err := other.List(ctx)
+		t.AssertNil(err)
+		t.Assert(len(listResponse.GetOutput()), 0)
+
+		_, err = dashvector.NewLocalClient(dashvector.ClientWithHttpClient(&http.Client{}))
+		t.Assert(err.Error(), "httpClient is unsupported by local client")
+		_, err = dashvector.NewLocalClient(dashvector.ClientWithScheme("http"))
+		t.Assert(err.Error(), "scheme is unsupported by local client")
+	})
+}
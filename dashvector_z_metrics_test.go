@@ -0,0 +1,97 @@
+package dashvector_test
+
+import (
+	"context"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/test/gtest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Metrics(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var observations []dashvector.Observation
+		var invocations int
+		local, err := dashvector.NewLocalClient(dashvector.ClientWithChunkSize(1),
+			dashvector.ClientWithInterceptors(dashvector.InterceptorFunc(
+				func(ctx context.Context, invocation *dashvector.Invocation,
+					next dashvector.Invoker) (dashvector.Response, error) {
+					invocations++
+					return next(ctx, invocation)
+				})),
+			dashvector.ClientWithMetrics(dashvector.MetricsFunc(
+				func(_ context.Context, observation *dashvector.Observation) {
+					observations = append(observations, *observation)
+				})))
+		t.AssertNil(err)
+		_, err = local.Create(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(err)
+		partition := local.GetCollection("c").GetPartition()
+		_, err = partition.Insert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(1, 1)),
+			dashvector.WithDocument(dashvector.WithId("2"), dashvector.WithVector(1, 2)))
+		t.AssertNil(err)
+		_, err = partition.Insert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(1, 1)),
+			dashvector.WithDocument(dashvector.WithId("3"), dashvector.WithVector(1, 3)))
+		t.AssertNil(err)
+		_, err = local.Desc(ctx, "missing")
+		t.AssertNil(err)
+
+		t.Assert(len(observations), 4)
+		t.Assert(invocations, 4)
+		t.Assert(observations[0].Operation, dashvector.OperationCollectionCreate)
+		t.Assert(observations[0].Collection, "c")
+		t.Assert(observations[0].Failed(), false)
+		t.Assert(observations[1].Operation, dashvector.OperationDocsInsert)
+		t.Assert(observations[1].DocsWritten, 2)
+		t.Assert(observations[1].WriteUnits, 2)
+		t.Assert(observations[2].DocsWritten, 1)
+		t.Assert(observations[3].Failed(), true)
+		t.AssertNE(observations[3].Code, dashvector.CodeSuccess)
+	})
+}
+
+func Test_PrometheusMetrics(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		metrics := dashvector.NewPrometheusMetrics(dashvector.PrometheusWithBuckets(1, 0.1))
+		metrics.Observe(ctx, &dashvector.Observation{Operation: dashvector.OperationDocsUpsert,
+			Collection: "c", Duration: time.Millisecond * 50, DocsWritten: 3, WriteUnits: 3})
+		metrics.Observe(ctx, &dashvector.Observation{Operation: dashvector.OperationDocsUpsert,
+			Collection: "c", Duration: time.Millisecond * 500, Code: dashvector.CodeDuplicateKey})
+		metrics.Observe(ctx, &dashvector.Observation{Operation: dashvector.OperationDocsQuery,
+			Collection: `a"b`, Duration: time.Second * 2, ReadUnits: 5})
+
+		var builder strings.Builder
+		_, err := metrics.WriteTo(&builder)
+		t.AssertNil(err)
+		text := builder.String()
+		for _, line := range []string{
+			"# TYPE dashvector_requests_total counter",
+			`dashvector_requests_total{operation="docs.upsert",collection="c"} 2`,
+			`dashvector_requests_total{operation="docs.query",collection="a\"b"} 1`,
+			`dashvector_request_errors_total{operation="docs.upsert",collection="c"} 1`,
+			`dashvector_response_codes_total{operation="docs.upsert",collection="c",code="-2027"} 1`,
+			"# TYPE dashvector_request_duration_seconds histogram",
+			`dashvector_request_duration_seconds_bucket{operation="docs.upsert",collection="c",le="0.1"} 1`,
+			`dashvector_request_duration_seconds_bucket{operation="docs.upsert",collection="c",le="1"} 2`,
+			`dashvector_request_duration_seconds_bucket{operation="docs.query",collection="a\"b",le="1"} 0`,
+			`dashvector_request_duration_seconds_bucket{operation="docs.query",collection="a\"b",le="+Inf"} 1`,
+			`dashvector_request_duration_seconds_sum{operation="docs.upsert",collection="c"} 0.55`,
+			`dashvector_request_duration_seconds_count{operation="docs.upsert",collection="c"} 2`,
+			`dashvector_docs_written_total{operation="docs.upsert",collection="c"} 3`,
+			`dashvector_read_units_total{operation="docs.query",collection="a\"b"} 5`,
+			`dashvector_write_units_total{operation="docs.upsert",collection="c"} 3`,
+		} {
+			t.Assert(strings.Contains(text, line+"\n"), true)
+		}
+
+		recorder := httptest.NewRecorder()
+		metrics.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		t.Assert(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8")
+		t.Assert(recorder.Body.String(), text)
+	})
+}
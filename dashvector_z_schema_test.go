@@ -0,0 +1,226 @@
+package dashvector_test
+
+import (
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/CharLemAznable/dashvector-sdk-go/filter"
+	"github.com/gogf/gf/v2/test/gtest"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_Schema_EnsureCollection(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var created int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPost {
+				atomic.StoreInt32(&created, 1)
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id"}`))
+				return
+			}
+			if r.URL.Path == "/v1/collections/missing" && atomic.LoadInt32(&created) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code":-2021,"message":"not exist","request_id":"id"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":{` +
+				`"name":"c","dimension":4,"dtype":"FLOAT","metric":"cosine","status":"SERVING",` +
+				`"fields_schema":{"name":"STRING","age":"INT"},` +
+				`"vectors_schema":{"proxima_vector":{"dimension":4,"dtype":"FLOAT","metric":"cosine","quantize_type":""}}}}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+
+		ensureResponse, err := c.EnsureCollection(ctx, "exists",
+			dashvector.WithDimension(4),
+			dashvector.WithFieldSchema("name", dashvector.FieldTypeString),
+			dashvector.WithFieldSchema("age", dashvector.FieldTypeInt))
+		t.AssertNil(err)
+		t.Assert(ensureResponse.GetCode(), 0)
+		t.Assert(atomic.LoadInt32(&created), 0)
+
+		_, err = c.EnsureCollection(ctx, "exists",
+			dashvector.WithDimension(8),
+			dashvector.WithMetric(dashvector.MetricEuclidean),
+			dashvector.WithFieldSchema("name", dashvector.FieldTypeString),
+			dashvector.WithFieldSchema("weight", dashvector.FieldTypeFloat))
+		var mismatchErr *dashvector.SchemaMismatchError
+		t.Assert(errors.As(err, &mismatchErr), true)
+		t.Assert(mismatchErr.Collection, "exists")
+		t.Assert(mismatchErr.Diffs, []dashvector.SchemaDiff{
+			{Field: "dimension", Desired: "8", Actual: "4"},
+			{Field: "metric", Desired: "euclidean", Actual: "cosine"},
+			{Field: "fields_schema.age", Desired: "", Actual: "INT"},
+			{Field: "fields_schema.weight", Desired: "FLOAT", Actual: ""},
+		})
+
+		ensureResponse, err = c.EnsureCollection(ctx, "missing", dashvector.WithDimension(4))
+		t.AssertNil(err)
+		t.Assert(ensureResponse.GetCode(), 0)
+		t.Assert(atomic.LoadInt32(&created), 1)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":{` +
+				`"name":"c","dimension":4,"dtype":"INT","metric":"dotproduct","status":"SERVING",` +
+				`"vectors_schema":{"proxima_vector":{"dimension":4,"dtype":"INT","metric":"dotproduct",` +
+				`"quantize_type":"DT_VECTOR_INT8"}}}}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		_, err = c.EnsureCollection(ctx, "c", dashvector.WithDimension(4))
+		t.AssertNil(err)
+		_, err = c.EnsureCollection(ctx, "c", dashvector.WithDimension(4),
+			dashvector.WithMetric(dashvector.MetricCosine))
+		var mismatchErr *dashvector.SchemaMismatchError
+		t.Assert(errors.As(err, &mismatchErr), true)
+		t.Assert(mismatchErr.Diffs, []dashvector.SchemaDiff{
+			{Field: "metric", Desired: "cosine", Actual: "dotproduct"},
+		})
+	})
+}
+
+func Test_Schema_Validation(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var writes int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":{` +
+					`"name":"c","dimension":0,"status":"SERVING",` +
+					`"fields_schema":{"name":"STRING","age":"INT","weight":"FLOAT"},` +
+					`"vectors_schema":{"title":{"dimension":2},"body":{"dimension":3}}}}`))
+				return
+			}
+			atomic.AddInt32(&writes, 1)
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[]}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		collection := c.GetCollection("schema")
+		partition := collection.GetPartition("p")
+		meta, err := collection.LoadSchema(ctx)
+		t.AssertNil(err)
+		t.Assert(meta.GetName(), "c")
+
+		_, err = partition.Upsert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"),
+				dashvector.WithSchemaVector("title", 0.1, 0.2),
+				dashvector.WithField("age", 1), dashvector.WithField("weight", 2)),
+			dashvector.WithDocument(dashvector.WithId("2"),
+				dashvector.WithSchemaVector("title", 0.1, 0.2, 0.3)),
+			dashvector.WithDocument(dashvector.WithId("3"),
+				dashvector.WithSchemaVector("summary", 0.1)),
+			dashvector.WithDocument(dashvector.WithId("4"),
+				dashvector.WithSchemaVector("body", 0.1, 0.2, 0.3),
+				dashvector.WithField("age", "old")),
+			dashvector.WithDocument(dashvector.WithId("5"), dashvector.WithVector(0.1)))
+		var validationErr *dashvector.ValidationError
+		t.Assert(errors.As(err, &validationErr), true)
+		t.Assert(validationErr.Violations, []dashvector.DocumentViolation{
+			{Index: 1, Id: "2", Reason: "vector title has dimension 3, expected 2"},
+			{Index: 2, Id: "3", Reason: "vector summary is not defined in collection schema"},
+			{Index: 3, Id: "4", Reason: "field age has type string, expected INT"},
+			{Index: 4, Id: "5", Reason: "vector is not defined in collection schema"},
+		})
+
+		_, err = partition.Query(ctx, dashvector.QueryWithSchemaVector("body", []float32{0.1}))
+		t.AssertNE(err, nil)
+		t.Assert(err.Error(), "collection schema: vector body has dimension 1, expected 3")
+		_, err = partition.GroupQuery(ctx, "name",
+			dashvector.GroupQueryWithVector(0.1, 0.2), dashvector.GroupQueryWithSchemaVector("body"))
+		t.Assert(err.Error(), "collection schema: vector body has dimension 2, expected 3")
+		_, err = partition.Query(ctx, dashvector.QueryWithId("1"),
+			dashvector.QueryWithFilter("age > 1 and"))
+		var syntaxErr *filter.SyntaxError
+		t.Assert(errors.As(err, &syntaxErr), true)
+		t.Assert(syntaxErr.Pos, 11)
+		_, err = partition.GroupQuery(ctx, "name",
+			dashvector.GroupQueryWithFilterExpr(filter.Eq("age", "old")))
+		var fieldErr *filter.FieldError
+		t.Assert(errors.As(err, &fieldErr), true)
+		t.Assert(fieldErr.Field, "age")
+		t.Assert(atomic.LoadInt32(&writes), 0)
+
+		_, err = partition.Upsert(ctx, dashvector.WithDocument(dashvector.WithId("1"),
+			dashvector.WithSchemaVector("title", 0.1, 0.2)))
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&writes), 1)
+		_, err = partition.Query(ctx, dashvector.QueryWithId("1"),
+			dashvector.QueryWithFilterExpr(filter.And(filter.Gt("age", 1), filter.Like("name", "a%"))))
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&writes), 2)
+		_, err = partition.Query(ctx, dashvector.QueryWithId("1"),
+			dashvector.QueryWithOutputFields("name", "color"))
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&writes), 3)
+
+		collection.ClearSchema()
+		_, err = partition.Query(ctx, dashvector.QueryWithId("1"),
+			dashvector.QueryWithOutputFields("color"))
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&writes), 4)
+	})
+}
+
+func Test_Schema_DecodeFields(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":{` +
+					`"name":"c","dimension":2,"status":"SERVING",` +
+					`"fields_schema":{"name":"STRING","age":"INT","weight":"FLOAT","alive":"BOOL"}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[{"id":"1","score":0.5,` +
+				`"fields":{"name":"a","age":30,"weight":60,"alive":true,"extra":1.5}}]}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		collection := c.GetCollection("decode")
+
+		queryResponse, err := collection.Query(ctx, dashvector.QueryWithId("1"))
+		t.AssertNil(err)
+		d := queryResponse.GetOutput()[0]
+		name, ok := d.GetString("name")
+		t.Assert(ok, true)
+		t.Assert(name, "a")
+		age, ok := d.GetInt64("age")
+		t.Assert(ok, true)
+		t.Assert(age, 30)
+		extra, ok := d.GetFloat64("extra")
+		t.Assert(ok, true)
+		t.Assert(extra, 1.5)
+		_, ok = d.GetInt64("extra")
+		t.Assert(ok, false)
+		alive, ok := d.GetBool("alive")
+		t.Assert(ok, true)
+		t.Assert(alive, true)
+		_, ok = d.GetString("missing")
+		t.Assert(ok, false)
+
+		_, err = collection.LoadSchema(ctx)
+		t.AssertNil(err)
+		queryResponse, err = collection.Query(ctx, dashvector.QueryWithId("1"))
+		t.AssertNil(err)
+		fields := queryResponse.GetOutput()[0].GetFields()
+		t.AssertEQ(fields["age"], int64(30))
+		t.AssertEQ(fields["weight"], float64(60))
+		t.AssertEQ(fields["alive"], true)
+		t.AssertEQ(fields["name"], "a")
+	})
+}
@@ -0,0 +1,72 @@
+package dashvector_test
+
+import (
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/test/gtest"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type structBase struct {
+	Id   string `dashvector:"id,id"`
+	Name string `dashvector:"name,STRING"`
+}
+
+type structDoc struct {
+	structBase
+	Age       int               `dashvector:"age"`
+	Weight    *float64          `dashvector:"weight,FLOAT"`
+	Married   bool              `dashvector:"married"`
+	Vector    []float32         `dashvector:",vector,dim=4,metric=euclidean,quantize=DT_VECTOR_INT8"`
+	Embedding []float32         `dashvector:"embedding,vector,dim=8,metric=cosine"`
+	Sparse    map[int32]float32 `dashvector:",sparse"`
+	Ignored   string            `dashvector:"-"`
+	Untagged  string
+}
+
+func Test_Struct_Schema(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var body *gjson.Json
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bytes, _ := io.ReadAll(r.Body)
+			body = gjson.New(bytes)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id"}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+
+		schema, err := dashvector.StructSchema(structDoc{})
+		t.AssertNil(err)
+		_, err = c.Create(ctx, "c", schema)
+		t.AssertNil(err)
+		t.Assert(body.Get("dimension").Int(), 4)
+		t.Assert(body.Get("metric").String(), "euclidean")
+		t.Assert(body.Get("extra_params.quantize_type").String(), "DT_VECTOR_INT8")
+		t.Assert(body.Get("fields_schema").MapStrStr(), map[string]string{
+			"name": "STRING", "age": "INT", "weight": "FLOAT", "married": "BOOL",
+		})
+		t.Assert(body.Get("vectors_schema.embedding.dimension").Int(), 8)
+		t.Assert(body.Get("vectors_schema.embedding.metric").String(), "cosine")
+
+		_, err = dashvector.StructSchema(struct {
+			Tags []string `dashvector:"tags"`
+		}{})
+		t.AssertNE(err, nil)
+		_, err = dashvector.StructSchema(struct {
+			Age int `dashvector:"age,STRING"`
+		}{})
+		t.AssertNE(err, nil)
+		_, err = dashvector.StructSchema(struct {
+			Vector []float32 `dashvector:",vector"`
+		}{})
+		t.AssertNE(err, nil)
+		_, err = dashvector.StructSchema("not struct")
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,84 @@
+package dashvector_test
+
+import (
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/CharLemAznable/dashvector-sdk-go/dashvectortest"
+	"github.com/gogf/gf/v2/test/gtest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"testing"
+)
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func Test_Tracing(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		server := dashvectortest.NewServer()
+		defer server.Close()
+		capture := &headerCapture{transport: server.Client().Transport}
+		local, err := server.NewClient(
+			dashvector.ClientWithHttpClient(&http.Client{Transport: capture}),
+			dashvector.ClientWithTracerProvider(provider),
+			dashvector.ClientWithChunkSize(1))
+		t.AssertNil(err)
+
+		_, err = local.Create(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(err)
+		partition := local.GetCollection("c").GetPartition()
+		_, err = partition.Upsert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(1, 1)),
+			dashvector.WithDocument(dashvector.WithId("2"), dashvector.WithVector(1, 2)))
+		t.AssertNil(err)
+		_, err = partition.Query(ctx, dashvector.QueryWithVector(1, 1), dashvector.QueryWithTopk(1))
+		t.AssertNil(err)
+		_, err = local.Stats(ctx, "missing")
+		t.AssertNil(err)
+
+		spans := recorder.Ended()
+		t.Assert(len(spans), 6)
+		t.Assert(spans[0].Name(), string(dashvector.OperationCollectionCreate))
+		t.Assert(spanAttributes(spans[0])["dashvector.collection"].AsString(), "c")
+		t.Assert(spanAttributes(spans[0])["dashvector.response.code"].AsInt64(), dashvector.CodeSuccess)
+
+		t.Assert(spans[3].Name(), string(dashvector.OperationDocsUpsert))
+		upsert := spanAttributes(spans[3])
+		t.Assert(upsert["db.system"].AsString(), "dashvector")
+		t.Assert(upsert["dashvector.partition"].AsString(), "default")
+		t.Assert(upsert["dashvector.doc_count"].AsInt64(), 2)
+		t.Assert(upsert["dashvector.write_units"].AsInt64(), 2)
+		t.AssertNE(upsert["dashvector.request_id"].AsString(), "")
+		for _, chunk := range spans[1:3] {
+			t.Assert(chunk.Name(), string(dashvector.OperationDocsUpsert)+".chunk")
+			t.Assert(chunk.Parent().SpanID(), spans[3].SpanContext().SpanID())
+			t.Assert(spanAttributes(chunk)["dashvector.doc_count"].AsInt64(), 1)
+			t.Assert(spanAttributes(chunk)["dashvector.write_units"].AsInt64(), 1)
+			t.Assert(spanAttributes(chunk)["dashvector.retry"].AsInt64(), 0)
+		}
+
+		t.Assert(spans[4].Name(), string(dashvector.OperationDocsQuery))
+		query := spanAttributes(spans[4])
+		t.Assert(query["dashvector.topk"].AsInt64(), 1)
+		t.Assert(query["dashvector.doc_count"].AsInt64(), 1)
+
+		t.Assert(spans[5].Name(), string(dashvector.OperationCollectionStats))
+		t.Assert(spans[5].Status().Code, codes.Error)
+		t.AssertNE(spanAttributes(spans[5])["dashvector.response.code"].AsInt64(), dashvector.CodeSuccess)
+
+		t.Assert(len(capture.headers), 5)
+		for i, span := range []sdktrace.ReadOnlySpan{spans[0], spans[1], spans[2], spans[4], spans[5]} {
+			traceparent := capture.headers[i].Get("traceparent")
+			t.Assert(traceparent[3:35], span.SpanContext().TraceID().String())
+		}
+	})
+}
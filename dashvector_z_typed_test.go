@@ -0,0 +1,87 @@
+package dashvector_test
+
+import (
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/test/gtest"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_Typed_Partition(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var body *gjson.Json
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bytes, _ := io.ReadAll(r.Body)
+			body = gjson.New(bytes)
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/v1/collections/c/docs":
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":{"1":` +
+						`{"id":"1","vector":[0.1,0.2,0.3,0.4],"fields":{"name":"a","age":18.0,"weight":60.5}}}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[]}`))
+			case "/v1/collections/c/query":
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[` +
+					`{"id":"1","score":0.5,"fields":{"name":"a","age":18,"married":true},` +
+					`"vectors":{"embedding":[1,2,3,4,5,6,7,8]},"sparse_vector":{"1":0.5}}]}`))
+			}
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		partition, err := dashvector.NewTypedPartition[structDoc](c.GetCollection("c"))
+		t.AssertNil(err)
+
+		weight := 60.5
+		_, err = partition.Insert(ctx, structDoc{
+			structBase: structBase{Id: "1", Name: "a"},
+			Age:        18,
+			Weight:     &weight,
+			Vector:     []float32{0.1, 0.2, 0.3, 0.4},
+			Embedding:  []float32{1, 2, 3, 4, 5, 6, 7, 8},
+			Sparse:     map[int32]float32{1: 0.5},
+			Untagged:   "untagged",
+		})
+		t.AssertNil(err)
+		doc := body.GetJson("docs.0")
+		t.Assert(doc.Get("id").String(), "1")
+		t.Assert(doc.Get("vector").Float32s(), []float32{0.1, 0.2, 0.3, 0.4})
+		t.Assert(doc.Get("vectors.embedding").Float32s(), []float32{1, 2, 3, 4, 5, 6, 7, 8})
+		t.Assert(doc.Get("sparse_vector.1").Float32(), 0.5)
+		t.Assert(doc.Get("fields").Map(), map[string]any{
+			"name": "a", "age": 18, "weight": 60.5, "married": false,
+		})
+
+		docs, err := partition.Get(ctx, "1")
+		t.AssertNil(err)
+		t.Assert(docs["1"].Id, "1")
+		t.Assert(docs["1"].Name, "a")
+		t.Assert(docs["1"].Age, 18)
+		t.Assert(*docs["1"].Weight, 60.5)
+		t.Assert(docs["1"].Vector, []float32{0.1, 0.2, 0.3, 0.4})
+
+		scored, err := partition.Query(ctx, dashvector.QueryWithVector(0.1, 0.2, 0.3, 0.4))
+		t.AssertNil(err)
+		t.Assert(len(scored), 1)
+		t.Assert(scored[0].Score, 0.5)
+		t.Assert(scored[0].Doc.Married, true)
+		t.Assert(scored[0].Doc.Weight, nil)
+		t.Assert(scored[0].Doc.Embedding, []float32{1, 2, 3, 4, 5, 6, 7, 8})
+		t.Assert(scored[0].Doc.Sparse, map[int32]float32{1: 0.5})
+
+		pointerPartition, err := dashvector.NewTypedPartition[*structDoc](c.GetCollection("c"))
+		t.AssertNil(err)
+		pointerDocs, err := pointerPartition.Get(ctx, "1")
+		t.AssertNil(err)
+		t.Assert(pointerDocs["1"].Age, 18)
+
+		_, err = dashvector.NewTypedPartition[string](c.GetCollection("c"))
+		t.AssertNE(err, nil)
+	})
+}
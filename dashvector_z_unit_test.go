@@ -3,9 +3,23 @@ package dashvector_test
 import (
 	"context"
 	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/CharLemAznable/dashvector-sdk-go/dashvectortest"
+	"github.com/gogf/gf/v2/frame/g"
 )
 
 var (
 	ctx    = context.TODO()
-	client = dashvector.NewClient(ctx)
+	client = newClient()
 )
+
+// newClient connects to the configured cluster, or to an in-memory fake server when none is configured.
+func newClient() dashvector.Client {
+	if g.Cfg().MustGetWithEnv(ctx, "dashvector.clusterEndpoint").IsEmpty() {
+		c, err := dashvectortest.NewServer().NewClient()
+		if err != nil {
+			panic(err)
+		}
+		return c
+	}
+	return dashvector.NewClient(ctx)
+}
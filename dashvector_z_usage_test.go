@@ -0,0 +1,121 @@
+package dashvector_test
+
+import (
+	"context"
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/test/gtest"
+	"testing"
+	"time"
+)
+
+func Test_UsageMeter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		meter := dashvector.NewUsageMeter()
+		local, err := dashvector.NewLocalClient(dashvector.ClientWithUsageMeter(meter))
+		t.AssertNil(err)
+		_, err = local.Create(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(err)
+		collection := local.GetCollection("c")
+		_, err = collection.Create(ctx, "p")
+		t.AssertNil(err)
+		_, err = collection.Upsert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(1, 1)),
+			dashvector.WithDocument(dashvector.WithId("2"), dashvector.WithVector(1, 2)))
+		t.AssertNil(err)
+		_, err = collection.GetPartition("p").Upsert(ctx,
+			dashvector.WithDocument(dashvector.WithId("3"), dashvector.WithVector(1, 3)))
+		t.AssertNil(err)
+		_, err = collection.Query(ctx, dashvector.QueryWithVector(1, 1))
+		t.AssertNil(err)
+		groupQueryResponse, err := collection.GroupQuery(ctx, "title", dashvector.GroupQueryWithVector(1, 1))
+		t.AssertNil(err)
+		t.Assert(groupQueryResponse.GetUsage().GetReadUnits(), 1)
+
+		t.Assert(meter.PartitionUsage("c", "default"), dashvector.Usage{ReadUnits: 3, WriteUnits: 2})
+		t.Assert(meter.PartitionUsage("c", "p"), dashvector.Usage{WriteUnits: 1})
+		t.Assert(meter.CollectionUsage("c"), dashvector.Usage{ReadUnits: 3, WriteUnits: 3})
+		t.Assert(meter.Total(), dashvector.Usage{ReadUnits: 3, WriteUnits: 3})
+		t.Assert(len(meter.Snapshot()), 2)
+
+		snapshot := meter.Reset()
+		t.Assert(snapshot[dashvector.UsageKey{Collection: "c", Partition: "p"}], dashvector.Usage{WriteUnits: 1})
+		t.Assert(len(meter.Snapshot()), 0)
+		t.Assert(meter.Total(), dashvector.Usage{})
+	})
+}
+
+func Test_UsageMeter_Budget(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		meter := dashvector.NewUsageMeter(dashvector.UsageWithWriteBudget(2, time.Hour))
+		local, err := dashvector.NewLocalClient(dashvector.ClientWithUsageMeter(meter))
+		t.AssertNil(err)
+		_, err = local.Create(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(err)
+		collection := local.GetCollection("c")
+		_, err = collection.Upsert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(1, 1)),
+			dashvector.WithDocument(dashvector.WithId("2"), dashvector.WithVector(1, 2)))
+		t.AssertNil(err)
+
+		_, err = collection.Upsert(ctx,
+			dashvector.WithDocument(dashvector.WithId("3"), dashvector.WithVector(1, 3)))
+		var budgetErr *dashvector.BudgetExceededError
+		t.Assert(errors.As(err, &budgetErr), true)
+		t.Assert(budgetErr.Operation, dashvector.OperationDocsUpsert)
+		t.Assert(budgetErr.Unit, "write")
+		t.Assert(budgetErr.Limit, 2)
+		t.Assert(budgetErr.RetryAfter > 0, true)
+
+		queryResponse, err := collection.Query(ctx, dashvector.QueryWithVector(1, 1))
+		t.AssertNil(err)
+		t.Assert(len(queryResponse.GetOutput()), 2)
+		t.Assert(meter.Total(), dashvector.Usage{ReadUnits: 2, WriteUnits: 2})
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		meter := dashvector.NewUsageMeter(
+			dashvector.UsageWithReadBudget(1, time.Millisecond*100),
+			dashvector.UsageWithThrottle(true))
+		local, err := dashvector.NewLocalClient(dashvector.ClientWithUsageMeter(meter))
+		t.AssertNil(err)
+		_, err = local.Create(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(err)
+		collection := local.GetCollection("c")
+		_, err = collection.Get(ctx, "1")
+		t.AssertNil(err)
+
+		start := time.Now()
+		_, err = collection.Get(ctx, "1")
+		t.AssertNil(err)
+		t.Assert(time.Since(start) >= time.Millisecond*50, true)
+
+		timeout, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+		defer cancel()
+		_, err = collection.Get(timeout, "1")
+		t.Assert(errors.Is(err, context.DeadlineExceeded), true)
+		t.Assert(meter.Total(), dashvector.Usage{ReadUnits: 2})
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		meter := dashvector.NewUsageMeter(
+			dashvector.UsageWithReadBudget(2, 0),
+			dashvector.UsageWithThrottle(true))
+		local, err := dashvector.NewLocalClient(dashvector.ClientWithUsageMeter(meter))
+		t.AssertNil(err)
+		_, err = local.Create(ctx, "c", dashvector.WithDimension(2))
+		t.AssertNil(err)
+		collection := local.GetCollection("c")
+		for i := 0; i < 2; i++ {
+			_, err = collection.Get(ctx, "1")
+			t.AssertNil(err)
+		}
+		time.Sleep(time.Millisecond * 10)
+		_, err = collection.Get(ctx, "1")
+		var budgetErr *dashvector.BudgetExceededError
+		t.Assert(errors.As(err, &budgetErr), true)
+		t.Assert(budgetErr.RetryAfter, time.Duration(0))
+		t.Assert(err.Error(), "dashvector docs.get rejected: read unit budget 2 exceeded")
+		t.Assert(meter.Total(), dashvector.Usage{ReadUnits: 2})
+	})
+}
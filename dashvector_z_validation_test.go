@@ -0,0 +1,60 @@
+package dashvector_test
+
+import (
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/test/gtest"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_Documents_StrictValidation(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var count int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&count, 1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":[]}`))
+		}))
+		defer server.Close()
+
+		docs := []dashvector.DocumentsConfig{
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(0.1)),
+			dashvector.WithDocument(dashvector.WithId("2")),
+			dashvector.WithDocument(dashvector.WithId("3"), dashvector.WithVector(float32(math.NaN()))),
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(0.2)),
+			dashvector.WithDocument(dashvector.WithId("5"), dashvector.WithVector(0.1),
+				dashvector.WithSchemaVector("embedding")),
+		}
+
+		c, err := newServerClient(server)
+		t.AssertNil(err)
+		_, err = c.GetCollection("strict").Insert(ctx, docs[:2]...)
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&count), 1)
+
+		c, err = newServerClient(server, dashvector.ClientWithStrictValidation(true))
+		t.AssertNil(err)
+		_, err = c.GetCollection("strict").Insert(ctx, docs...)
+		var validationErr *dashvector.ValidationError
+		t.Assert(errors.As(err, &validationErr), true)
+		t.Assert(len(validationErr.Violations), 4)
+		t.Assert(validationErr.Violations[0].Index, 1)
+		t.Assert(validationErr.Violations[0].Reason, "vector is required")
+		t.Assert(validationErr.Violations[1].Index, 2)
+		t.Assert(validationErr.Violations[1].Id, "3")
+		t.Assert(validationErr.Violations[2].Index, 3)
+		t.Assert(validationErr.Violations[2].Reason, "duplicate id 1 in batch")
+		t.Assert(validationErr.Violations[3].Index, 4)
+		t.Assert(validationErr.Violations[3].Reason, "vector embedding is empty")
+		t.Assert(atomic.LoadInt32(&count), 1)
+
+		_, err = c.GetCollection("strict").Update(ctx,
+			dashvector.WithDocument(dashvector.WithVector(0.1)))
+		t.Assert(errors.As(err, &validationErr), true)
+		t.Assert(validationErr.Violations[0].Reason, "id is required")
+	})
+}
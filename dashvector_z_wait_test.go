@@ -0,0 +1,192 @@
+package dashvector_test
+
+import (
+	"context"
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/gogf/gf/v2/test/gtest"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Wait_CreateServing(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var descCount int32
+		var status atomic.Value
+		status.Store("INITIALIZED")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodPost:
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id"}`))
+			case http.MethodGet:
+				output := `{"name":"c","status":"INITIALIZED"}`
+				if r.URL.Path == "/v1/collections/c/partitions/p" {
+					output = `"INITIALIZED"`
+				}
+				if atomic.AddInt32(&descCount, 1) > 2 {
+					output = `{"name":"c","status":"` + status.Load().(string) + `"}`
+					if r.URL.Path == "/v1/collections/c/partitions/p" {
+						output = `"` + status.Load().(string) + `"`
+					}
+				}
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":` + output + `}`))
+			}
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server, dashvector.ClientWithWait(
+			dashvector.WaitWithInterval(time.Millisecond),
+			dashvector.WaitWithMaxInterval(time.Millisecond*5),
+		))
+		t.AssertNil(err)
+
+		status.Store("SERVING")
+		createResponse, err := c.CreateServing(ctx, "c", dashvector.WithDimension(4))
+		t.AssertNil(err)
+		t.Assert(createResponse.(dashvector.CollectionDescResponse).GetOutput().GetStatus(), dashvector.StatusServing)
+		t.Assert(atomic.LoadInt32(&descCount), 3)
+
+		atomic.StoreInt32(&descCount, 0)
+		status.Store("ERROR")
+		_, err = c.CreateServing(ctx, "c", dashvector.WithDimension(4))
+		var statusErr *dashvector.FailedStatusError
+		t.Assert(errors.As(err, &statusErr), true)
+		t.Assert(statusErr.Collection, "c")
+		t.Assert(statusErr.Status, dashvector.StatusError)
+
+		atomic.StoreInt32(&descCount, 0)
+		_, err = c.GetCollection("c").CreateServing(ctx, "p")
+		t.Assert(errors.As(err, &statusErr), true)
+		t.Assert(statusErr.Partition, "p")
+
+		atomic.StoreInt32(&descCount, 0)
+		status.Store("INITIALIZED")
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		_, err = c.CreateServing(timeoutCtx, "c", dashvector.WithDimension(4))
+		t.Assert(errors.Is(err, context.DeadlineExceeded), true)
+	})
+}
+
+func Test_Wait_DeleteServing(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var listCount int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodDelete:
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id"}`))
+			case http.MethodGet:
+				output := `["c","other"]`
+				if r.URL.Path == "/v1/collections/c/partitions" {
+					output = `["default","p"]`
+				}
+				if atomic.AddInt32(&listCount, 1) > 2 {
+					output = `["other"]`
+				}
+				_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":` + output + `}`))
+			}
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server, dashvector.ClientWithWait(
+			dashvector.WaitWithInterval(time.Millisecond),
+		))
+		t.AssertNil(err)
+
+		deleteResponse, err := c.DeleteServing(ctx, "c")
+		t.AssertNil(err)
+		t.Assert(deleteResponse.GetCode(), 0)
+		t.Assert(atomic.LoadInt32(&listCount), 3)
+
+		atomic.StoreInt32(&listCount, 0)
+		deleteResponse, err = c.GetCollection("c").DeleteServing(ctx, "p")
+		t.AssertNil(err)
+		t.Assert(deleteResponse.GetCode(), 0)
+		t.Assert(atomic.LoadInt32(&listCount), 3)
+
+		t.AssertNil(c.WaitUntilGone(ctx, "c"))
+	})
+}
+
+func Test_Wait_Indexed(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var statsCount int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			completeness := []string{"0.2", "0.6", "1.0"}[atomic.AddInt32(&statsCount, 1)-1]
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":` +
+				`{"total_doc_count":"10","index_completeness":` + completeness + `}}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server, dashvector.ClientWithWait(
+			dashvector.WaitWithInterval(time.Millisecond),
+		))
+		t.AssertNil(err)
+
+		var progress []float64
+		statsResponse, err := c.WaitIndexed(ctx, "c", 1.0, func(stats dashvector.CollectionStats) {
+			progress = append(progress, stats.GetIndexCompleteness())
+		})
+		t.AssertNil(err)
+		t.Assert(statsResponse.GetOutput().GetIndexCompleteness(), 1.0)
+		t.Assert(progress, []float64{0.2, 0.6, 1.0})
+	})
+}
+
+func Test_Wait_ErrorCode(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var requestCount int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":-2021,"message":"not found","request_id":"id"}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server, dashvector.ClientWithWait(
+			dashvector.WaitWithInterval(time.Millisecond),
+		))
+		t.AssertNil(err)
+
+		err = c.WaitUntilGone(ctx, "c")
+		t.Assert(errors.Is(err, dashvector.ErrCollectionNotFound), true)
+		err = c.GetCollection("c").WaitUntilGone(ctx, "p")
+		t.Assert(errors.Is(err, dashvector.ErrCollectionNotFound), true)
+		_, err = c.WaitForCollectionStatus(ctx, "c", dashvector.StatusServing)
+		t.Assert(errors.Is(err, dashvector.ErrCollectionNotFound), true)
+		_, err = c.GetCollection("c").WaitForPartitionStatus(ctx, "p", dashvector.StatusServing)
+		t.Assert(errors.Is(err, dashvector.ErrCollectionNotFound), true)
+		statsResponse, err := c.WaitIndexed(ctx, "c", 1)
+		t.Assert(errors.Is(err, dashvector.ErrCollectionNotFound), true)
+		t.Assert(statsResponse.GetCode(), dashvector.CodeInexistentCollection)
+		t.Assert(atomic.LoadInt32(&requestCount), 5)
+	})
+}
+
+func Test_Wait_IntervalFloor(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var requestCount int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":0,"message":"","request_id":"id","output":{"name":"c","status":"INITIALIZED"}}`))
+		}))
+		defer server.Close()
+
+		c, err := newServerClient(server, dashvector.ClientWithWait(
+			dashvector.WaitWithInterval(0),
+			dashvector.WaitWithMultiplier(0.1),
+			dashvector.WaitWithTimeout(time.Millisecond*100),
+		))
+		t.AssertNil(err)
+		_, err = c.WaitForCollectionStatus(ctx, "c", dashvector.StatusServing)
+		t.Assert(errors.Is(err, context.DeadlineExceeded), true)
+		t.AssertLE(atomic.LoadInt32(&requestCount), 11)
+	})
+}
@@ -0,0 +1,59 @@
+package dashvectortest
+
+import (
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/CharLemAznable/dashvector-sdk-go/internal/inmemory"
+	"net/http/httptest"
+	"strings"
+)
+
+const defaultApiKey = "dashvectortest"
+
+type ServerConfig func(*Server)
+
+func ServerWithApiKey(apiKey string) ServerConfig {
+	return func(server *Server) {
+		server.apiKey = apiKey
+	}
+}
+
+// Server is an in-memory DashVector cluster served over httptest,
+// implementing the REST endpoints used by the SDK with brute-force search.
+type Server struct {
+	*httptest.Server
+	apiKey  string
+	handler *inmemory.Server
+}
+
+func NewServer(configs ...ServerConfig) *Server {
+	server := &Server{apiKey: defaultApiKey}
+	for _, cfg := range configs {
+		cfg(server)
+	}
+	server.handler = inmemory.New(server.apiKey)
+	server.Server = httptest.NewServer(server.handler)
+	return server
+}
+
+// Endpoint returns the cluster endpoint without scheme, as expected by the SDK.
+func (s *Server) Endpoint() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func (s *Server) ApiKey() string {
+	return s.apiKey
+}
+
+// NewClient returns an SDK client connected to this server.
+func (s *Server) NewClient(configs ...dashvector.ClientConfig) (dashvector.Client, error) {
+	return dashvector.NewClientWithOptions(s.Endpoint(), s.apiKey,
+		append([]dashvector.ClientConfig{
+			dashvector.ClientWithScheme("http"),
+			dashvector.ClientWithHttpClient(s.Client()),
+		}, configs...)...)
+}
+
+// Reset drops all collections.
+func (s *Server) Reset() {
+	s.handler.Reset()
+}
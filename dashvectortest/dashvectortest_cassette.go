@@ -0,0 +1,223 @@
+package dashvectortest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// ModeReplay serves responses from an existing cassette and never touches the network.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real transport and records every interaction.
+	ModeRecord
+)
+
+// Cassette is the on-disk form of recorded interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest holds the request parts used for matching.
+// The auth header is never recorded.
+type CassetteRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   string `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type RecorderConfig func(*Recorder)
+
+func RecorderWithMode(mode Mode) RecorderConfig {
+	return func(recorder *Recorder) {
+		recorder.mode = mode
+	}
+}
+
+// RecorderWithTransport sets the transport used in record mode, http.DefaultTransport by default.
+func RecorderWithTransport(transport http.RoundTripper) RecorderConfig {
+	return func(recorder *Recorder) {
+		recorder.transport = transport
+	}
+}
+
+// Recorder is an http.RoundTripper recording interactions into a cassette file,
+// or replaying them offline. Requests are matched on method, path (with query)
+// and normalized JSON body; identical requests replay in recorded order.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	mutex     sync.Mutex
+	cassette  *Cassette
+	replayed  []bool
+}
+
+// NewRecorder creates a Recorder for the cassette at path.
+// In replay mode the cassette must exist.
+func NewRecorder(path string, configs ...RecorderConfig) (*Recorder, error) {
+	recorder := &Recorder{path: path, transport: http.DefaultTransport, cassette: &Cassette{}}
+	for _, cfg := range configs {
+		cfg(recorder)
+	}
+	if recorder.mode == ModeReplay {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(content, recorder.cassette); err != nil {
+			return nil, fmt.Errorf("dashvectortest: invalid cassette %s: %w", path, err)
+		}
+		recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+	}
+	return recorder, nil
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := readBody(request)
+	if err != nil {
+		return nil, err
+	}
+	cassetteRequest := CassetteRequest{
+		Method: request.Method,
+		Path:   request.URL.RequestURI(),
+		Body:   normalizeBody(body),
+	}
+	if r.mode == ModeReplay {
+		return r.replay(request, cassetteRequest)
+	}
+	return r.record(request, body, cassetteRequest)
+}
+
+func (r *Recorder) replay(request *http.Request, cassetteRequest CassetteRequest) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if !r.replayed[i] && interaction.Request == cassetteRequest {
+			r.replayed[i] = true
+			return newResponse(request, interaction.Response), nil
+		}
+	}
+	return nil, fmt.Errorf("dashvectortest: no recorded interaction for %s %s",
+		cassetteRequest.Method, cassetteRequest.Path)
+}
+
+func (r *Recorder) record(request *http.Request, body []byte, cassetteRequest CassetteRequest) (*http.Response, error) {
+	outgoing := request.Clone(request.Context())
+	outgoing.Body = io.NopCloser(bytes.NewReader(body))
+	response, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	cassetteResponse := CassetteResponse{
+		StatusCode: response.StatusCode,
+		Header:     http.Header{"Content-Type": response.Header.Values("Content-Type")},
+		Body:       string(responseBody),
+	}
+	r.mutex.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions,
+		&Interaction{Request: cassetteRequest, Response: cassetteResponse})
+	r.mutex.Unlock()
+	return newResponse(request, cassetteResponse), nil
+}
+
+// Save writes the recorded interactions to the cassette file. It is a no-op in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+	r.mutex.Lock()
+	content, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, content, 0o644)
+}
+
+// Client returns an http.Client using this Recorder as transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// NewClient returns an SDK client whose requests go through this Recorder.
+// In replay mode clusterEndpoint and apiKey are not used for matching.
+func (r *Recorder) NewClient(clusterEndpoint, apiKey string, configs ...dashvector.ClientConfig) (dashvector.Client, error) {
+	return dashvector.NewClientWithOptions(clusterEndpoint, apiKey,
+		append([]dashvector.ClientConfig{dashvector.ClientWithHttpClient(r.Client())}, configs...)...)
+}
+
+func readBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+	defer func() { _ = request.Body.Close() }()
+	return io.ReadAll(request.Body)
+}
+
+// normalizeBody re-encodes JSON bodies with sorted keys, so key order does not affect matching.
+func normalizeBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return string(body)
+	}
+	return string(normalized)
+}
+
+func newResponse(request *http.Request, cassetteResponse CassetteResponse) *http.Response {
+	header := cassetteResponse.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cassetteResponse.StatusCode, http.StatusText(cassetteResponse.StatusCode)),
+		StatusCode:    cassetteResponse.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(cassetteResponse.Body))),
+		ContentLength: int64(len(cassetteResponse.Body)),
+		Request:       request,
+	}
+}
@@ -0,0 +1,74 @@
+package dashvectortest_test
+
+import (
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/CharLemAznable/dashvector-sdk-go/dashvectortest"
+	"github.com/gogf/gf/v2/test/gtest"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Recorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "recorder.json")
+	run := func(t *gtest.T, client dashvector.Client) {
+		_, err := client.CreateServing(ctx, "c", dashvector.WithDimension(2),
+			dashvector.WithFieldSchema("name", dashvector.FieldTypeString))
+		t.AssertNil(err)
+		collection := client.GetCollection("c")
+		_, err = collection.Insert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(1, 0),
+				dashvector.WithField("name", "alice")))
+		t.AssertNil(err)
+		statsResponse, err := client.Stats(ctx, "c")
+		t.AssertNil(err)
+		t.Assert(statsResponse.GetOutput().GetTotalDocCount(), 1)
+		_, err = collection.Insert(ctx,
+			dashvector.WithDocument(dashvector.WithId("2"), dashvector.WithVector(0, 1),
+				dashvector.WithField("name", "bob")))
+		t.AssertNil(err)
+		statsResponse, err = client.Stats(ctx, "c")
+		t.AssertNil(err)
+		t.Assert(statsResponse.GetOutput().GetTotalDocCount(), 2)
+		queryResponse, err := collection.Query(ctx, dashvector.QueryWithVector(1, 0),
+			dashvector.QueryWithFilter("name = 'alice'"))
+		t.AssertNil(err)
+		t.Assert(len(queryResponse.GetOutput()), 1)
+		t.Assert(queryResponse.GetOutput()[0].GetId(), "1")
+		getResponse, err := collection.Get(ctx, "2")
+		t.AssertNil(err)
+		t.Assert(len(getResponse.GetOutput()), 1)
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		server := dashvectortest.NewServer()
+		recorder, err := dashvectortest.NewRecorder(path,
+			dashvectortest.RecorderWithMode(dashvectortest.ModeRecord),
+			dashvectortest.RecorderWithTransport(server.Client().Transport))
+		t.AssertNil(err)
+		client, err := recorder.NewClient(server.Endpoint(), server.ApiKey(),
+			dashvector.ClientWithScheme("http"), dashvector.ClientWithCodeError(true))
+		t.AssertNil(err)
+		run(t, client)
+		t.AssertNil(recorder.Save())
+		server.Close()
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		recorder, err := dashvectortest.NewRecorder(path)
+		t.AssertNil(err)
+		t.Assert(recorder.Mode(), dashvectortest.ModeReplay)
+		client, err := recorder.NewClient("replay", "replay", dashvector.ClientWithCodeError(true))
+		t.AssertNil(err)
+		run(t, client)
+
+		_, err = client.Stats(ctx, "c")
+		t.AssertNE(err, nil)
+		_, err = client.Desc(ctx, "c")
+		t.AssertNE(err, nil)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		_, err := dashvectortest.NewRecorder(filepath.Join(filepath.Dir(path), "missing.json"))
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,119 @@
+package dashvectortest_test
+
+import (
+	"context"
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go"
+	"github.com/CharLemAznable/dashvector-sdk-go/dashvectortest"
+	"github.com/CharLemAznable/dashvector-sdk-go/filter"
+	"github.com/gogf/gf/v2/test/gtest"
+	"testing"
+)
+
+var ctx = context.TODO()
+
+func Test_Server(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := dashvectortest.NewServer()
+		defer server.Close()
+		client, err := server.NewClient(dashvector.ClientWithCodeError(true))
+		t.AssertNil(err)
+
+		_, err = client.CreateServing(ctx, "c",
+			dashvector.WithDimension(2),
+			dashvector.WithMetric(dashvector.MetricEuclidean),
+			dashvector.WithFieldSchema("name", dashvector.FieldTypeString),
+			dashvector.WithFieldSchema("age", dashvector.FieldTypeInt))
+		t.AssertNil(err)
+		_, err = client.Create(ctx, "c", dashvector.WithDimension(2))
+		t.Assert(errors.Is(err, dashvector.ErrCollectionExists), true)
+		_, err = client.Desc(ctx, "missing")
+		t.Assert(errors.Is(err, dashvector.ErrCollectionNotFound), true)
+
+		collection := client.GetCollection("c")
+		writeResponse, err := collection.Insert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(0, 0),
+				dashvector.WithField("name", "alice"), dashvector.WithField("age", 20)),
+			dashvector.WithDocument(dashvector.WithId("2"), dashvector.WithVector(1, 1),
+				dashvector.WithField("name", "bob"), dashvector.WithField("age", 30)),
+			dashvector.WithDocument(dashvector.WithId("3"), dashvector.WithVector(3, 3),
+				dashvector.WithField("name", "bella"), dashvector.WithField("age", 40)))
+		t.AssertNil(err)
+		t.AssertNil(writeResponse.Err())
+		t.Assert(writeResponse.GetUsage().GetWriteUnits(), 3)
+
+		writeResponse, err = collection.Insert(ctx,
+			dashvector.WithDocument(dashvector.WithId("1"), dashvector.WithVector(0, 0)))
+		t.AssertNil(err)
+		t.Assert(writeResponse.Failed()[0].GetCode(), dashvector.CodeDuplicateKey)
+
+		_, err = collection.Upsert(ctx, dashvector.WithDocument(dashvector.WithId("4"), dashvector.WithVector(1)))
+		t.Assert(errors.Is(err, dashvector.ErrMismatchedDimension), true)
+
+		queryResponse, err := collection.Query(ctx,
+			dashvector.QueryWithVector(1, 1),
+			dashvector.QueryWithTopk(2),
+			dashvector.QueryWithFilterExpr(filter.Gt("age", 10)))
+		t.AssertNil(err)
+		t.Assert(len(queryResponse.GetOutput()), 2)
+		t.Assert(queryResponse.GetOutput()[0].GetId(), "2")
+		t.Assert(queryResponse.GetOutput()[0].GetScore(), 0)
+		t.Assert(queryResponse.GetOutput()[1].GetId(), "1")
+		t.Assert(queryResponse.GetOutput()[1].GetScore(), 2)
+
+		queryResponse, err = collection.Query(ctx,
+			dashvector.QueryWithId("1"),
+			dashvector.QueryWithFilter("name like 'b%' and age >= 40"),
+			dashvector.QueryWithOutputFields("name"))
+		t.AssertNil(err)
+		t.Assert(len(queryResponse.GetOutput()), 1)
+		t.Assert(queryResponse.GetOutput()[0].GetId(), "3")
+		t.Assert(queryResponse.GetOutput()[0].GetFields(), map[string]any{"name": "bella"})
+
+		_, err = collection.Query(ctx, dashvector.QueryWithFilter("age >"))
+		t.Assert(errors.Is(err, dashvector.ErrInvalidFilter), true)
+
+		groupResponse, err := collection.GroupQuery(ctx, "name",
+			dashvector.GroupQueryWithVector(3, 3),
+			dashvector.GroupQueryWithCount(2))
+		t.AssertNil(err)
+		t.Assert(len(groupResponse.GetOutput()), 2)
+		t.Assert(groupResponse.GetOutput()[0].GetGroupId(), "bella")
+		t.Assert(groupResponse.GetOutput()[1].GetGroupId(), "bob")
+
+		_, err = collection.GetPartition("missing").Query(ctx, dashvector.QueryWithVector(1, 1))
+		t.Assert(errors.Is(err, dashvector.ErrPartitionNotFound), true)
+
+		statsResponse, err := client.Stats(ctx, "c")
+		t.AssertNil(err)
+		t.Assert(statsResponse.GetOutput().GetTotalDocCount(), 3)
+
+		_, err = collection.Drop(ctx, "1", "2")
+		t.AssertNil(err)
+		getResponse, err := collection.Get(ctx, "1", "2", "3")
+		t.AssertNil(err)
+		t.Assert(len(getResponse.GetOutput()), 1)
+
+		_, err = client.DeleteServing(ctx, "c")
+		t.AssertNil(err)
+	})
+}
+
+func Test_Server_ApiKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := dashvectortest.NewServer(dashvectortest.ServerWithApiKey("secret"))
+		defer server.Close()
+		client, err := dashvector.NewClientWithOptions(server.Endpoint(), "wrong",
+			dashvector.ClientWithScheme("http"))
+		t.AssertNil(err)
+		listResponse, err := client.List(ctx)
+		t.AssertNil(err)
+		t.Assert(listResponse.GetMessage(), "invalid api key")
+
+		client, err = server.NewClient()
+		t.AssertNil(err)
+		listResponse, err = client.List(ctx)
+		t.AssertNil(err)
+		t.Assert(listResponse.GetOutput(), []string{})
+	})
+}
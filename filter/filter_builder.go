@@ -0,0 +1,237 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type Expr interface {
+	String() string
+	negate() Expr
+}
+
+func Eq(field string, value any) Expr {
+	if value == nil {
+		return IsNull(field)
+	}
+	return newCompare(field, OpEq, value)
+}
+
+func Ne(field string, value any) Expr {
+	if value == nil {
+		return IsNotNull(field)
+	}
+	return newCompare(field, OpNe, value)
+}
+
+func Gt(field string, value any) Expr {
+	return newCompare(field, OpGt, value)
+}
+
+func Ge(field string, value any) Expr {
+	return newCompare(field, OpGe, value)
+}
+
+func Lt(field string, value any) Expr {
+	return newCompare(field, OpLt, value)
+}
+
+func Le(field string, value any) Expr {
+	return newCompare(field, OpLe, value)
+}
+
+// In matches any of values, without values it builds a filter that matches no docs.
+func In(field string, values ...any) Expr {
+	if len(values) == 0 {
+		return And(IsNull(field), IsNotNull(field))
+	}
+	exprs := make([]Expr, 0, len(values))
+	for _, value := range values {
+		exprs = append(exprs, Eq(field, value))
+	}
+	return Or(exprs...)
+}
+
+func Like(field string, pattern string) Expr {
+	return &Compare{Field: field, Op: OpLike, Value: pattern}
+}
+
+func IsNull(field string) Expr {
+	return &Null{Field: field}
+}
+
+func IsNotNull(field string) Expr {
+	return &Null{Field: field, Not: true}
+}
+
+func And(exprs ...Expr) Expr {
+	return newLogical(OpAnd, exprs)
+}
+
+func Or(exprs ...Expr) Expr {
+	return newLogical(OpOr, exprs)
+}
+
+func Not(expr Expr) Expr {
+	if expr == nil {
+		return nil
+	}
+	return expr.negate()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	OpEq      = "="
+	OpNe      = "!="
+	OpGt      = ">"
+	OpGe      = ">="
+	OpLt      = "<"
+	OpLe      = "<="
+	OpLike    = "like"
+	OpNotLike = "not like"
+	OpAnd     = "and"
+	OpOr      = "or"
+)
+
+var negatedOps = map[string]string{
+	OpEq:      OpNe,
+	OpNe:      OpEq,
+	OpGt:      OpLe,
+	OpGe:      OpLt,
+	OpLt:      OpGe,
+	OpLe:      OpGt,
+	OpLike:    OpNotLike,
+	OpNotLike: OpLike,
+	OpAnd:     OpOr,
+	OpOr:      OpAnd,
+}
+
+type Compare struct {
+	Field string
+	Op    string
+	Value any
+}
+
+// newCompare renders the value once, so that an invalid literal panics where the filter is built.
+func newCompare(field string, op string, value any) Expr {
+	Literal(value)
+	return &Compare{Field: field, Op: op, Value: value}
+}
+
+func (c *Compare) String() string {
+	return c.Field + " " + c.Op + " " + Literal(c.Value)
+}
+
+func (c *Compare) negate() Expr {
+	return &Compare{Field: c.Field, Op: negatedOps[c.Op], Value: c.Value}
+}
+
+type Null struct {
+	Field string
+	Not   bool
+}
+
+func (n *Null) String() string {
+	if n.Not {
+		return n.Field + " is not null"
+	}
+	return n.Field + " is null"
+}
+
+func (n *Null) negate() Expr {
+	return &Null{Field: n.Field, Not: !n.Not}
+}
+
+type Logical struct {
+	Op    string
+	Exprs []Expr
+}
+
+func newLogical(op string, exprs []Expr) Expr {
+	operands := make([]Expr, 0, len(exprs))
+	for _, expr := range exprs {
+		if expr != nil {
+			operands = append(operands, expr)
+		}
+	}
+	switch len(operands) {
+	case 0:
+		return nil
+	case 1:
+		return operands[0]
+	}
+	return &Logical{Op: op, Exprs: operands}
+}
+
+func (l *Logical) String() string {
+	parts := make([]string, 0, len(l.Exprs))
+	for _, expr := range l.Exprs {
+		if _, ok := expr.(*Logical); ok {
+			parts = append(parts, "("+expr.String()+")")
+		} else {
+			parts = append(parts, expr.String())
+		}
+	}
+	return strings.Join(parts, " "+l.Op+" ")
+}
+
+func (l *Logical) negate() Expr {
+	exprs := make([]Expr, 0, len(l.Exprs))
+	for _, expr := range l.Exprs {
+		exprs = append(exprs, expr.negate())
+	}
+	return &Logical{Op: negatedOps[l.Op], Exprs: exprs}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Literal renders value by its kind before its String method, and panics
+// for NaN, infinities and malformed json.Number, which have no literal form.
+func Literal(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case json.Number:
+		if _, err := v.Float64(); err != nil || !json.Valid([]byte(v)) {
+			panic(fmt.Errorf("filter: invalid number literal %q", string(v)))
+		}
+		return string(v)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32:
+		return formatFloat(rv.Float(), 32)
+	case reflect.Float64:
+		return formatFloat(rv.Float(), 64)
+	case reflect.String:
+		return Quote(rv.String())
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	}
+	if v, ok := value.(fmt.Stringer); ok {
+		return Quote(v.String())
+	}
+	return Quote(fmt.Sprint(value))
+}
+
+func formatFloat(f float64, bitSize int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Errorf("filter: invalid number literal %v", f))
+	}
+	return strconv.FormatFloat(f, 'f', -1, bitSize)
+}
+
+func Quote(s string) string {
+	return "'" + quoteReplacer.Replace(s) + "'"
+}
+
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
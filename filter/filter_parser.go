@@ -0,0 +1,363 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type SyntaxError struct {
+	Filter  string
+	Pos     int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("filter syntax error at position %d: %s", e.Pos, e.Message)
+}
+
+func Parse(filter string) (Expr, error) {
+	p := &parser{lexer: &lexer{input: filter}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.token.kind == tokenEOF {
+		return nil, p.errorf("empty filter")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.token.kind != tokenEOF {
+		return nil, p.errorf("unexpected %s", p.token)
+	}
+	return expr, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return strconv.Quote(t.text)
+}
+
+func (t token) keyword(keyword string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && isSpace(l.input[l.pos]) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+	c := l.input[l.pos]
+	switch {
+	case c == '(':
+		l.pos++
+		return token{kind: tokenLParen, text: "(", pos: start}, nil
+	case c == ')':
+		l.pos++
+		return token{kind: tokenRParen, text: ")", pos: start}, nil
+	case c == ',':
+		l.pos++
+		return token{kind: tokenComma, text: ",", pos: start}, nil
+	case c == '\'' || c == '"':
+		return l.lexString(c)
+	case isDigit(c) || ((c == '-' || c == '+' || c == '.') && l.pos+1 < len(l.input) &&
+		(isDigit(l.input[l.pos+1]) || l.input[l.pos+1] == '.')):
+		return l.lexNumber()
+	case isIdentStart(c):
+		for l.pos < len(l.input) && isIdentPart(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: l.input[start:l.pos], pos: start}, nil
+	}
+	for _, op := range []string{OpGe, OpLe, OpNe, "<>", OpEq, OpGt, OpLt} {
+		if strings.HasPrefix(l.input[l.pos:], op) {
+			l.pos += len(op)
+			if op == "<>" {
+				op = OpNe
+			}
+			return token{kind: tokenOp, text: op, pos: start}, nil
+		}
+	}
+	return token{}, &SyntaxError{Filter: l.input, Pos: start,
+		Message: fmt.Sprintf("unexpected character %q", c)}
+}
+
+func (l *lexer) lexString(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+	var builder strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch c {
+		case '\\':
+			if l.pos+1 >= len(l.input) {
+				return token{}, &SyntaxError{Filter: l.input, Pos: l.pos, Message: "unterminated escape"}
+			}
+			builder.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+		case quote:
+			l.pos++
+			value := builder.String()
+			return token{kind: tokenString, text: l.input[start:l.pos], value: value, pos: start}, nil
+		default:
+			builder.WriteByte(c)
+			l.pos++
+		}
+	}
+	return token{}, &SyntaxError{Filter: l.input, Pos: start, Message: "unterminated string"}
+}
+
+func (l *lexer) lexNumber() (token, error) {
+	start := l.pos
+	if c := l.input[l.pos]; c == '-' || c == '+' {
+		l.pos++
+	}
+	for l.pos < len(l.input) && (isDigit(l.input[l.pos]) || strings.IndexByte(".eE", l.input[l.pos]) >= 0 ||
+		((l.input[l.pos] == '-' || l.input[l.pos] == '+') && strings.IndexByte("eE", l.input[l.pos-1]) >= 0)) {
+		l.pos++
+	}
+	text := l.input[start:l.pos]
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return token{kind: tokenNumber, text: text, value: i, pos: start}, nil
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return token{kind: tokenNumber, text: text, value: f, pos: start}, nil
+	}
+	return token{}, &SyntaxError{Filter: l.input, Pos: start, Message: fmt.Sprintf("invalid number %q", text)}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.'
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type parser struct {
+	lexer *lexer
+	token token
+}
+
+func (p *parser) next() (err error) {
+	p.token, err = p.lexer.next()
+	return err
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Filter: p.lexer.input, Pos: p.token.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	return p.parseLogical(OpOr, p.parseAnd)
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	return p.parseLogical(OpAnd, p.parseUnary)
+}
+
+func (p *parser) parseLogical(op string, operand func() (Expr, error)) (Expr, error) {
+	expr, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	exprs := []Expr{expr}
+	for p.token.keyword(op) {
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+		if expr, err = operand(); err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return newLogical(op, exprs), nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.token.keyword("not") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(expr), nil
+	}
+	if p.token.kind == tokenLParen {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.token.kind != tokenRParen {
+			return nil, p.errorf("expected \")\", got %s", p.token)
+		}
+		return expr, p.next()
+	}
+	return p.parsePredicate()
+}
+
+func (p *parser) parsePredicate() (Expr, error) {
+	if p.token.kind != tokenIdent || isKeyword(p.token.text) {
+		return nil, p.errorf("expected field name, got %s", p.token)
+	}
+	field := p.token.text
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.token.kind == tokenOp:
+		op := p.token.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return &Compare{Field: field, Op: op, Value: value}, nil
+	case p.token.keyword("is"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		not := p.token.keyword("not")
+		if not {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if !p.token.keyword("null") {
+			return nil, p.errorf("expected null, got %s", p.token)
+		}
+		return &Null{Field: field, Not: not}, p.next()
+	}
+	not := p.token.keyword("not")
+	if not {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case p.token.keyword("like"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.token.kind != tokenString {
+			return nil, p.errorf("expected string pattern, got %s", p.token)
+		}
+		expr := &Compare{Field: field, Op: OpLike, Value: p.token.value}
+		if not {
+			expr.Op = OpNotLike
+		}
+		return expr, p.next()
+	case p.token.keyword("in"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		expr := In(field, values...)
+		if not {
+			expr = Not(expr)
+		}
+		return expr, nil
+	}
+	return nil, p.errorf("expected operator after %s, got %s", field, p.token)
+}
+
+func (p *parser) parseList() ([]any, error) {
+	if p.token.kind != tokenLParen {
+		return nil, p.errorf("expected \"(\", got %s", p.token)
+	}
+	var values []any
+	for {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		switch p.token.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return values, p.next()
+		}
+		return nil, p.errorf("expected \",\" or \")\", got %s", p.token)
+	}
+}
+
+func (p *parser) parseLiteral() (any, error) {
+	var value any
+	switch {
+	case p.token.kind == tokenString || p.token.kind == tokenNumber:
+		value = p.token.value
+	case p.token.keyword("true"):
+		value = true
+	case p.token.keyword("false"):
+		value = false
+	default:
+		return nil, p.errorf("expected literal, got %s", p.token)
+	}
+	return value, p.next()
+}
+
+var keywords = map[string]bool{
+	"and": true, "or": true, "not": true, "like": true, "is": true,
+	"null": true, "in": true, "true": true, "false": true,
+}
+
+func isKeyword(text string) bool {
+	return keywords[strings.ToLower(text)]
+}
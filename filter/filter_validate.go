@@ -0,0 +1,101 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("filter field %s: %s", e.Field, e.Message)
+}
+
+// Validate checks fields referenced by expr against fields,
+// a map of field name to DashVector field type (BOOL, STRING, INT, FLOAT).
+func Validate(expr Expr, fields map[string]string) error {
+	switch e := expr.(type) {
+	case *Logical:
+		for _, operand := range e.Exprs {
+			if err := Validate(operand, fields); err != nil {
+				return err
+			}
+		}
+	case *Null:
+		if _, ok := fields[e.Field]; !ok {
+			return &FieldError{Field: e.Field, Message: "is not defined in schema"}
+		}
+	case *Compare:
+		fieldType, ok := fields[e.Field]
+		if !ok {
+			return &FieldError{Field: e.Field, Message: "is not defined in schema"}
+		}
+		if message := validateCompare(fieldType, e.Op, e.Value); message != "" {
+			return &FieldError{Field: e.Field, Message: message}
+		}
+	}
+	return nil
+}
+
+func validateCompare(fieldType string, op string, value any) string {
+	kind := literalKind(value)
+	switch fieldType {
+	case "STRING":
+		if kind != "STRING" {
+			return fmt.Sprintf("is STRING, got %s literal %s", kind, Literal(value))
+		}
+		return ""
+	case "INT":
+		if kind == "FLOAT" && isIntegral(value) {
+			kind = "INT"
+		}
+		if kind != "INT" {
+			return fmt.Sprintf("is INT, got %s literal %s", kind, Literal(value))
+		}
+	case "FLOAT":
+		if kind != "INT" && kind != "FLOAT" {
+			return fmt.Sprintf("is FLOAT, got %s literal %s", kind, Literal(value))
+		}
+	case "BOOL":
+		if kind != "BOOL" {
+			return fmt.Sprintf("is BOOL, got %s literal %s", kind, Literal(value))
+		}
+		if op != OpEq && op != OpNe {
+			return fmt.Sprintf("is BOOL, operator %s is not supported", op)
+		}
+		return ""
+	default:
+		return ""
+	}
+	if op == OpLike || op == OpNotLike {
+		return fmt.Sprintf("is %s, operator %s is not supported", fieldType, op)
+	}
+	return ""
+}
+
+func literalKind(value any) string {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String:
+		return "STRING"
+	case reflect.Bool:
+		return "BOOL"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INT"
+	case reflect.Float32, reflect.Float64:
+		return "FLOAT"
+	}
+	if _, ok := value.(fmt.Stringer); ok {
+		return "STRING"
+	}
+	return "UNKNOWN"
+}
+
+func isIntegral(value any) bool {
+	f := reflect.ValueOf(value).Float()
+	return f == math.Trunc(f) && !math.IsInf(f, 0)
+}
@@ -0,0 +1,66 @@
+package filter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/CharLemAznable/dashvector-sdk-go/filter"
+	"github.com/gogf/gf/v2/test/gtest"
+	"math"
+	"testing"
+)
+
+type color string
+
+type level int
+
+func (l level) String() string {
+	return "L"
+}
+
+type point struct{ x, y int }
+
+func (p point) String() string {
+	return fmt.Sprintf("%d,%d", p.x, p.y)
+}
+
+func Test_Builder(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(filter.Eq("name", "O'Brien").String(), `name = 'O\'Brien'`)
+		t.Assert(filter.Ne("path", `a\b`).String(), `path != 'a\\b'`)
+		t.Assert(filter.Gt("age", 18).String(), "age > 18")
+		t.Assert(filter.Ge("weight", 60.5).String(), "weight >= 60.5")
+		t.Assert(filter.Lt("score", float32(0.1)).String(), "score < 0.1")
+		t.Assert(filter.Le("big", 1e21).String(), "big <= 1000000000000000000000")
+		t.Assert(filter.Eq("alive", true).String(), "alive = true")
+		t.Assert(filter.Eq("color", color("red")).String(), "color = 'red'")
+		t.Assert(filter.Eq("age", level(3)).String(), "age = 3")
+		t.Assert(filter.Eq("age", json.Number("5")).String(), "age = 5")
+		t.Assert(filter.Eq("point", point{1, 2}).String(), "point = '1,2'")
+		t.Assert(filter.Eq("name", nil).String(), "name is null")
+		t.Assert(filter.Ne("name", nil).String(), "name is not null")
+		t.Assert(filter.Like("name", "abc%").String(), "name like 'abc%'")
+		t.Assert(filter.In("age", 1, 2).String(), "age = 1 or age = 2")
+		t.Assert(filter.In("age").String(), "age is null and age is not null")
+
+		expr := filter.And(
+			filter.Gt("age", 18),
+			filter.Or(filter.Eq("name", "a"), filter.Like("name", "b%")),
+			nil,
+		)
+		t.Assert(expr.String(), "age > 18 and (name = 'a' or name like 'b%')")
+		t.Assert(filter.Not(expr).String(), "age <= 18 or (name != 'a' and name not like 'b%')")
+		t.Assert(filter.Not(filter.IsNull("name")).String(), "name is not null")
+		t.Assert(filter.And(filter.Eq("a", 1)).String(), "a = 1")
+		t.Assert(filter.And(), nil)
+		t.Assert(filter.Not(nil), nil)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		for _, value := range []any{math.NaN(), math.Inf(1), float32(math.Inf(-1)), json.Number("NaN"), json.Number("0x10")} {
+			func() {
+				defer func() { t.AssertNE(recover(), nil) }()
+				filter.Gt("score", value)
+			}()
+		}
+	})
+}
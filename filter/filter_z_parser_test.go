@@ -0,0 +1,91 @@
+package filter_test
+
+import (
+	"errors"
+	"github.com/CharLemAznable/dashvector-sdk-go/filter"
+	"github.com/gogf/gf/v2/test/gtest"
+	"testing"
+)
+
+func Test_Parse(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		expr, err := filter.Parse(`age >= 18 AND (name = 'O\'Brien' or name like "b%") and weight < -1.5e2`)
+		t.AssertNil(err)
+		t.Assert(expr.String(), `age >= 18 and (name = 'O\'Brien' or name like 'b%') and weight < -150`)
+		logical, ok := expr.(*filter.Logical)
+		t.Assert(ok, true)
+		t.Assert(logical.Op, filter.OpAnd)
+		t.Assert(len(logical.Exprs), 3)
+		t.AssertEQ(logical.Exprs[0].(*filter.Compare).Value, int64(18))
+
+		expr, err = filter.Parse(`not (alive = true) and name is not null and id not in (1, 2) and tag not like 'x%'`)
+		t.AssertNil(err)
+		t.Assert(expr.String(), `alive != true and name is not null and (id != 1 and id != 2) and tag not like 'x%'`)
+
+		built := filter.And(filter.Gt("age", 18), filter.Or(filter.Eq("name", "a"), filter.IsNull("name")))
+		expr, err = filter.Parse(built.String())
+		t.AssertNil(err)
+		t.Assert(expr.String(), built.String())
+	})
+}
+
+func Test_Parse_SyntaxError(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for filterString, pos := range map[string]int{
+			"":                 0,
+			"age >":            5,
+			"age > 1 and":      11,
+			"(age > 1":         8,
+			"age ? 1":          4,
+			"name = 'abc":      7,
+			"age > 1 name = 2": 8,
+			"name like 1":      10,
+			"and = 1":          0,
+			"age in (1, ":      11,
+		} {
+			_, err := filter.Parse(filterString)
+			var syntaxErr *filter.SyntaxError
+			t.Assert(errors.As(err, &syntaxErr), true)
+			t.Assert(syntaxErr.Pos, pos)
+		}
+	})
+}
+
+func Test_Validate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		fields := map[string]string{"name": "STRING", "age": "INT", "weight": "FLOAT", "alive": "BOOL"}
+		valid := []string{
+			"name = 'a' and age > 1 and weight < 2 and alive = true",
+			"weight > 1 and age = 2.0 and name like 'a%' and name is null",
+		}
+		for _, filterString := range valid {
+			expr, err := filter.Parse(filterString)
+			t.AssertNil(err)
+			t.AssertNil(filter.Validate(expr, fields))
+		}
+		invalid := map[string]string{
+			"color = 'red'":   "filter field color: is not defined in schema",
+			"age = 'old'":     "filter field age: is INT, got STRING literal 'old'",
+			"age = 1.5":       "filter field age: is INT, got FLOAT literal 1.5",
+			"name = 1":        "filter field name: is STRING, got INT literal 1",
+			"weight like 'x'": "filter field weight: is FLOAT, got STRING literal 'x'",
+			"age like 1":      "",
+			"alive > true":    "filter field alive: is BOOL, operator > is not supported",
+			"weight = false":  "filter field weight: is FLOAT, got BOOL literal false",
+		}
+		for filterString, message := range invalid {
+			expr, err := filter.Parse(filterString)
+			if message == "" {
+				t.AssertNE(err, nil)
+				continue
+			}
+			t.AssertNil(err)
+			err = filter.Validate(expr, fields)
+			var fieldErr *filter.FieldError
+			t.Assert(errors.As(err, &fieldErr), true)
+			t.Assert(err.Error(), message)
+		}
+		t.Assert(filter.Validate(filter.Like("age", "1%"), fields).Error(),
+			"filter field age: is INT, got STRING literal '1%'")
+	})
+}
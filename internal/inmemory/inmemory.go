@@ -0,0 +1,182 @@
+// Package inmemory implements the DashVector REST API over in-memory state
+// with brute-force search, for local clients and offline tests.
+package inmemory
+
+import (
+	"encoding/json"
+	"github.com/gogf/gf/v2/util/guid"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	headerAuthToken   = "dashvector-auth-token"
+	headerContentType = "Content-Type"
+	pathPrefix        = "/v1/collections"
+)
+
+const (
+	codeSuccess              = 0
+	codeInvalidFilter        = -2015
+	codeMismatchedDimension  = -2019
+	codeInexistentCollection = -2021
+	codeInexistentPartition  = -2022
+	codeDuplicateCollection  = -2025
+	codeDuplicatePartition   = -2026
+	codeDuplicateKey         = -2027
+	codeInvalidBatchSize     = -2036
+	codeInvalidDimension     = -2037
+)
+
+const (
+	dataTypeFloat    = "FLOAT"
+	metricEuclidean  = "euclidean"
+	metricDotproduct = "dotproduct"
+	metricCosine     = "cosine"
+	statusServing    = "SERVING"
+	docOpInsert      = "insert"
+	docOpUpdate      = "update"
+	docOpUpsert      = "upsert"
+	docOpDelete      = "delete"
+)
+
+type Server struct {
+	apiKey      string
+	mutex       sync.RWMutex
+	collections map[string]*collection
+}
+
+func New(apiKey string) *Server {
+	return &Server{apiKey: apiKey, collections: make(map[string]*collection)}
+}
+
+// Reset drops all collections.
+func (s *Server) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.collections = make(map[string]*collection)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type response struct {
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	RequestId string `json:"request_id"`
+	Output    any    `json:"output,omitempty"`
+	Usage     *usage `json:"usage,omitempty"`
+}
+
+type usage struct {
+	ReadUnits  int `json:"read_units,omitempty"`
+	WriteUnits int `json:"write_units,omitempty"`
+}
+
+type apiError struct {
+	status  int
+	code    int
+	message string
+}
+
+func newApiError(status int, code int, message string) *apiError {
+	return &apiError{status: status, code: code, message: message}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(headerAuthToken) != s.apiKey {
+		writeResponse(w, http.StatusUnauthorized, &response{Code: -1, Message: "invalid api key"})
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, pathPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	segments := strings.FieldsFunc(strings.TrimPrefix(r.URL.Path, pathPrefix), func(c rune) bool { return c == '/' })
+	handler := s.route(r.Method, segments)
+	if handler == nil {
+		http.NotFound(w, r)
+		return
+	}
+	result, err := handler(r, segments)
+	if err != nil {
+		writeResponse(w, err.status, &response{Code: err.code, Message: err.message})
+		return
+	}
+	writeResponse(w, http.StatusOK, result)
+}
+
+type handlerFunc func(r *http.Request, segments []string) (*response, *apiError)
+
+func (s *Server) route(method string, segments []string) handlerFunc {
+	switch len(segments) {
+	case 0:
+		return map[string]handlerFunc{
+			http.MethodPost: s.createCollection,
+			http.MethodGet:  s.listCollections,
+		}[method]
+	case 1:
+		return map[string]handlerFunc{
+			http.MethodGet:    s.descCollection,
+			http.MethodDelete: s.deleteCollection,
+		}[method]
+	case 2:
+		switch segments[1] {
+		case "stats":
+			return map[string]handlerFunc{http.MethodGet: s.statsCollection}[method]
+		case "partitions":
+			return map[string]handlerFunc{
+				http.MethodPost: s.createPartition,
+				http.MethodGet:  s.listPartitions,
+			}[method]
+		case "docs":
+			return map[string]handlerFunc{
+				http.MethodPost:   s.insertDocs,
+				http.MethodPut:    s.updateDocs,
+				http.MethodGet:    s.getDocs,
+				http.MethodDelete: s.dropDocs,
+			}[method]
+		case "query":
+			return map[string]handlerFunc{http.MethodPost: s.queryDocs}[method]
+		case "query_group_by":
+			return map[string]handlerFunc{http.MethodPost: s.groupQueryDocs}[method]
+		}
+	case 3:
+		switch {
+		case segments[1] == "partitions":
+			return map[string]handlerFunc{
+				http.MethodGet:    s.descPartition,
+				http.MethodDelete: s.deletePartition,
+			}[method]
+		case segments[1] == "docs" && segments[2] == "upsert":
+			return map[string]handlerFunc{http.MethodPost: s.upsertDocs}[method]
+		}
+	case 4:
+		if segments[1] == "partitions" && segments[3] == "stats" {
+			return map[string]handlerFunc{http.MethodGet: s.statsPartition}[method]
+		}
+	}
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, result *response) {
+	result.RequestId = guid.S()
+	w.Header().Set(headerContentType, "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func decodeBody(r *http.Request, v any) *apiError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newApiError(http.StatusBadRequest, -1, "invalid request body: "+err.Error())
+	}
+	return nil
+}
+
+func successResponse(output any) *response {
+	return &response{Code: codeSuccess, Output: output}
+}
+
+func docsResponse(output any, u *usage) *response {
+	return &response{Code: codeSuccess, Message: "Success", Output: output, Usage: u}
+}
@@ -0,0 +1,261 @@
+package inmemory
+
+import (
+	"net/http"
+	"sort"
+)
+
+const (
+	defaultVectorName    = "proxima_vector"
+	defaultPartitionName = "default"
+)
+
+type collection struct {
+	Name          string
+	Dimension     int
+	DataType      string
+	Metric        string
+	FieldsSchema  map[string]string
+	VectorsSchema map[string]*vectorSchema
+	Named         bool
+	Partitions    map[string]*partition
+}
+
+type vectorSchema struct {
+	Dimension    int    `json:"dimension"`
+	DataType     string `json:"dtype"`
+	Metric       string `json:"metric"`
+	QuantizeType string `json:"quantize_type"`
+}
+
+type partition struct {
+	Docs map[string]*document
+}
+
+func newPartition() *partition {
+	return &partition{Docs: make(map[string]*document)}
+}
+
+type collectionCreateRequest struct {
+	Name         string            `json:"name"`
+	Dimension    int               `json:"dimension"`
+	DataType     string            `json:"dtype"`
+	Metric       string            `json:"metric"`
+	FieldsSchema map[string]string `json:"fields_schema"`
+	ExtraParams  *struct {
+		QuantizeType string `json:"quantize_type"`
+	} `json:"extra_params"`
+	VectorsSchema map[string]*vectorSchema `json:"vectors_schema"`
+}
+
+func (s *Server) createCollection(r *http.Request, _ []string) (*response, *apiError) {
+	request := &collectionCreateRequest{}
+	if err := decodeBody(r, request); err != nil {
+		return nil, err
+	}
+	if request.Name == "" {
+		return nil, newApiError(http.StatusBadRequest, -1, "collection name is required")
+	}
+	c := &collection{
+		Name:          request.Name,
+		FieldsSchema:  request.FieldsSchema,
+		VectorsSchema: make(map[string]*vectorSchema),
+		Partitions:    map[string]*partition{defaultPartitionName: newPartition()},
+	}
+	if c.FieldsSchema == nil {
+		c.FieldsSchema = make(map[string]string)
+	}
+	if len(request.VectorsSchema) > 0 {
+		c.Named = true
+		c.DataType = dataTypeFloat
+		c.Metric = metricEuclidean
+		for name, schema := range request.VectorsSchema {
+			if schema == nil || schema.Dimension <= 0 {
+				return nil, newApiError(http.StatusBadRequest, codeInvalidDimension, "invalid dimension")
+			}
+			c.VectorsSchema[name] = &vectorSchema{
+				Dimension:    schema.Dimension,
+				DataType:     defaultIfEmpty(schema.DataType, dataTypeFloat),
+				Metric:       defaultIfEmpty(schema.Metric, metricEuclidean),
+				QuantizeType: schema.QuantizeType,
+			}
+		}
+	} else {
+		if request.Dimension <= 0 {
+			return nil, newApiError(http.StatusBadRequest, codeInvalidDimension, "invalid dimension")
+		}
+		c.Dimension = request.Dimension
+		c.DataType = defaultIfEmpty(request.DataType, dataTypeFloat)
+		c.Metric = defaultIfEmpty(request.Metric, metricCosine)
+		schema := &vectorSchema{Dimension: c.Dimension, DataType: c.DataType, Metric: c.Metric}
+		if request.ExtraParams != nil {
+			schema.QuantizeType = request.ExtraParams.QuantizeType
+		}
+		c.VectorsSchema[defaultVectorName] = schema
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.collections[c.Name]; ok {
+		return nil, newApiError(http.StatusBadRequest, codeDuplicateCollection, "collection already exist")
+	}
+	s.collections[c.Name] = c
+	return successResponse(nil), nil
+}
+
+func (s *Server) listCollections(_ *http.Request, _ []string) (*response, *apiError) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return successResponse(sortedKeys(s.collections)), nil
+}
+
+func (s *Server) descCollection(_ *http.Request, segments []string) (*response, *apiError) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	c, err := s.collection(segments[0])
+	if err != nil {
+		return nil, err
+	}
+	partitions := make(map[string]string, len(c.Partitions))
+	for name := range c.Partitions {
+		partitions[name] = statusServing
+	}
+	return successResponse(map[string]any{
+		"name":           c.Name,
+		"dimension":      c.Dimension,
+		"dtype":          c.DataType,
+		"metric":         c.Metric,
+		"status":         statusServing,
+		"fields_schema":  c.FieldsSchema,
+		"vectors_schema": c.VectorsSchema,
+		"partitions":     partitions,
+	}), nil
+}
+
+func (s *Server) deleteCollection(_ *http.Request, segments []string) (*response, *apiError) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.collection(segments[0]); err != nil {
+		return nil, err
+	}
+	delete(s.collections, segments[0])
+	return successResponse(nil), nil
+}
+
+func (s *Server) statsCollection(_ *http.Request, segments []string) (*response, *apiError) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	c, err := s.collection(segments[0])
+	if err != nil {
+		return nil, err
+	}
+	var total int
+	partitions := make(map[string]any, len(c.Partitions))
+	for name, p := range c.Partitions {
+		total += len(p.Docs)
+		partitions[name] = map[string]any{"total_doc_count": len(p.Docs)}
+	}
+	return successResponse(map[string]any{
+		"total_doc_count":    total,
+		"index_completeness": 1.0,
+		"partitions":         partitions,
+	}), nil
+}
+
+func (s *Server) createPartition(r *http.Request, segments []string) (*response, *apiError) {
+	request := &struct {
+		Name string `json:"name"`
+	}{}
+	if err := decodeBody(r, request); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c, err := s.collection(segments[0])
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.Partitions[request.Name]; ok {
+		return nil, newApiError(http.StatusBadRequest, codeDuplicatePartition, "partition already exist")
+	}
+	c.Partitions[request.Name] = newPartition()
+	return successResponse(nil), nil
+}
+
+func (s *Server) listPartitions(_ *http.Request, segments []string) (*response, *apiError) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	c, err := s.collection(segments[0])
+	if err != nil {
+		return nil, err
+	}
+	return successResponse(sortedKeys(c.Partitions)), nil
+}
+
+func (s *Server) descPartition(_ *http.Request, segments []string) (*response, *apiError) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if _, err := s.partition(segments[0], segments[2]); err != nil {
+		return nil, err
+	}
+	return successResponse(statusServing), nil
+}
+
+func (s *Server) deletePartition(_ *http.Request, segments []string) (*response, *apiError) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.partition(segments[0], segments[2]); err != nil {
+		return nil, err
+	}
+	delete(s.collections[segments[0]].Partitions, segments[2])
+	return successResponse(nil), nil
+}
+
+func (s *Server) statsPartition(_ *http.Request, segments []string) (*response, *apiError) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	p, err := s.partition(segments[0], segments[2])
+	if err != nil {
+		return nil, err
+	}
+	return successResponse(map[string]any{"total_doc_count": len(p.Docs)}), nil
+}
+
+func (s *Server) collection(name string) (*collection, *apiError) {
+	c, ok := s.collections[name]
+	if !ok {
+		return nil, newApiError(http.StatusNotFound, codeInexistentCollection, "collection not exist")
+	}
+	return c, nil
+}
+
+func (s *Server) partition(collectionName, partitionName string) (*partition, *apiError) {
+	c, err := s.collection(collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if partitionName == "" {
+		partitionName = defaultPartitionName
+	}
+	p, ok := c.Partitions[partitionName]
+	if !ok {
+		return nil, newApiError(http.StatusNotFound, codeInexistentPartition, "partition not exist")
+	}
+	return p, nil
+}
+
+func defaultIfEmpty[T ~string](value, defaultValue T) T {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,173 @@
+package inmemory
+
+import (
+	"fmt"
+	"github.com/gogf/gf/v2/util/guid"
+	"net/http"
+	"strings"
+)
+
+type document struct {
+	Id           string               `json:"id"`
+	Vector       []float32            `json:"vector,omitempty"`
+	Vectors      map[string][]float32 `json:"vectors,omitempty"`
+	SparseVector map[string]float32   `json:"sparse_vector,omitempty"`
+	Fields       map[string]any       `json:"fields,omitempty"`
+	Score        float32              `json:"score"`
+}
+
+type docOpResult struct {
+	Id      string `json:"id"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	DocOp   string `json:"doc_op"`
+}
+
+type documentsWriteRequest struct {
+	Docs      []*document `json:"docs"`
+	Partition string      `json:"partition"`
+}
+
+type documentsDropRequest struct {
+	Ids       []string `json:"ids"`
+	Partition string   `json:"partition"`
+	DeleteAll bool     `json:"delete_all"`
+}
+
+func (s *Server) insertDocs(r *http.Request, segments []string) (*response, *apiError) {
+	return s.writeDocs(r, segments, docOpInsert)
+}
+
+func (s *Server) updateDocs(r *http.Request, segments []string) (*response, *apiError) {
+	return s.writeDocs(r, segments, docOpUpdate)
+}
+
+func (s *Server) upsertDocs(r *http.Request, segments []string) (*response, *apiError) {
+	return s.writeDocs(r, segments, docOpUpsert)
+}
+
+func (s *Server) writeDocs(r *http.Request, segments []string, op string) (*response, *apiError) {
+	request := &documentsWriteRequest{}
+	if err := decodeBody(r, request); err != nil {
+		return nil, err
+	}
+	if len(request.Docs) == 0 {
+		return nil, newApiError(http.StatusBadRequest, codeInvalidBatchSize, "docs is empty")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.partition(segments[0], request.Partition)
+	if err != nil {
+		return nil, err
+	}
+	c := s.collections[segments[0]]
+	for _, d := range request.Docs {
+		_, exists := p.Docs[d.Id]
+		if err = c.validateDocument(d, op == docOpInsert || !exists); err != nil {
+			return nil, err
+		}
+	}
+	results := make([]*docOpResult, 0, len(request.Docs))
+	for _, d := range request.Docs {
+		if d.Id == "" {
+			d.Id = guid.S()
+		}
+		d.Score = 0
+		_, exists := p.Docs[d.Id]
+		result := &docOpResult{Id: d.Id, DocOp: op}
+		switch {
+		case op == docOpInsert && exists:
+			result.Code, result.Message = codeDuplicateKey, "duplicate key"
+			results = append(results, result)
+			continue
+		case op == docOpUpsert:
+			result.DocOp = docOpInsert
+			if exists {
+				result.DocOp = docOpUpdate
+			}
+		}
+		if existing := p.Docs[d.Id]; existing != nil && len(d.Vector) == 0 && len(d.Vectors) == 0 {
+			d.Vector, d.Vectors = existing.Vector, existing.Vectors
+		}
+		p.Docs[d.Id] = d
+		results = append(results, result)
+	}
+	return docsResponse(results, &usage{WriteUnits: len(request.Docs)}), nil
+}
+
+func (s *Server) getDocs(r *http.Request, segments []string) (*response, *apiError) {
+	ids := strings.Split(r.URL.Query().Get("ids"), ",")
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	p, err := s.partition(segments[0], r.URL.Query().Get("partition"))
+	if err != nil {
+		return nil, err
+	}
+	output := make(map[string]*document)
+	for _, id := range ids {
+		if d, ok := p.Docs[id]; ok {
+			output[id] = d
+		}
+	}
+	return docsResponse(output, &usage{ReadUnits: maxInt(len(output), 1)}), nil
+}
+
+func (s *Server) dropDocs(r *http.Request, segments []string) (*response, *apiError) {
+	request := &documentsDropRequest{}
+	if err := decodeBody(r, request); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.partition(segments[0], request.Partition)
+	if err != nil {
+		return nil, err
+	}
+	if request.DeleteAll {
+		for id := range p.Docs {
+			delete(p.Docs, id)
+		}
+		return docsResponse([]*docOpResult{}, nil), nil
+	}
+	results := make([]*docOpResult, 0, len(request.Ids))
+	for _, id := range request.Ids {
+		delete(p.Docs, id)
+		results = append(results, &docOpResult{Id: id, DocOp: docOpDelete})
+	}
+	return docsResponse(results, &usage{WriteUnits: len(request.Ids)}), nil
+}
+
+func (c *collection) validateDocument(d *document, required bool) *apiError {
+	if !required && len(d.Vector) == 0 && len(d.Vectors) == 0 {
+		return nil
+	}
+	if !c.Named {
+		if len(d.Vector) != c.Dimension {
+			return newApiError(http.StatusBadRequest, codeMismatchedDimension,
+				fmt.Sprintf("mismatched dimension: %d, expected %d", len(d.Vector), c.Dimension))
+		}
+		return nil
+	}
+	if len(d.Vectors) == 0 {
+		return newApiError(http.StatusBadRequest, codeMismatchedDimension, "vectors is required")
+	}
+	for name, vector := range d.Vectors {
+		schema, ok := c.VectorsSchema[name]
+		if !ok {
+			return newApiError(http.StatusBadRequest, codeMismatchedDimension,
+				fmt.Sprintf("vector %s not exist", name))
+		}
+		if len(vector) != schema.Dimension {
+			return newApiError(http.StatusBadRequest, codeMismatchedDimension,
+				fmt.Sprintf("mismatched dimension of %s: %d, expected %d", name, len(vector), schema.Dimension))
+		}
+	}
+	return nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
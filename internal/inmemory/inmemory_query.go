@@ -0,0 +1,440 @@
+package inmemory
+
+import (
+	"encoding/json"
+	"github.com/CharLemAznable/dashvector-sdk-go/filter"
+	"github.com/gogf/gf/v2/util/gconv"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+const (
+	defaultTopk         = 10
+	defaultGroupCount   = 10
+	defaultGroupTopk    = 10
+	defaultRankConstant = 60
+)
+
+type vectorQuery struct {
+	Vector []float32 `json:"vector"`
+}
+
+type documentsQueryRequest struct {
+	Vector        []float32               `json:"vector"`
+	SparseVector  map[string]float32      `json:"sparse_vector"`
+	Id            string                  `json:"id"`
+	Topk          int                     `json:"topk"`
+	IncludeVector bool                    `json:"include_vector"`
+	Filter        string                  `json:"filter"`
+	OutputFields  []string                `json:"output_fields"`
+	Vectors       map[string]*vectorQuery `json:"vectors"`
+	Rerank        *struct {
+		RankerName   string          `json:"ranker_name"`
+		RankerParams json.RawMessage `json:"ranker_params"`
+	} `json:"rerank"`
+	Partition    string `json:"partition"`
+	GroupByField string `json:"group_by_field"`
+	GroupCount   int    `json:"group_count"`
+	GroupTopk    int    `json:"group_topk"`
+	VectorField  string `json:"vector_field"`
+}
+
+type group struct {
+	GroupId string      `json:"group_id"`
+	Docs    []*document `json:"docs"`
+}
+
+func (s *Server) queryDocs(r *http.Request, segments []string) (*response, *apiError) {
+	request := &documentsQueryRequest{}
+	if err := decodeBody(r, request); err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	docs, err := s.search(segments[0], request)
+	if err != nil {
+		return nil, err
+	}
+	topk := request.Topk
+	if topk <= 0 {
+		topk = defaultTopk
+	}
+	if len(docs) > topk {
+		docs = docs[:topk]
+	}
+	output := make([]*document, 0, len(docs))
+	for _, d := range docs {
+		output = append(output, outputDocument(d, request))
+	}
+	return docsResponse(output, &usage{ReadUnits: maxInt(len(output), 1)}), nil
+}
+
+func (s *Server) groupQueryDocs(r *http.Request, segments []string) (*response, *apiError) {
+	request := &documentsQueryRequest{}
+	if err := decodeBody(r, request); err != nil {
+		return nil, err
+	}
+	if request.GroupByField == "" {
+		return nil, newApiError(http.StatusBadRequest, -1, "group_by_field is required")
+	}
+	if request.VectorField != "" {
+		request.Vectors = map[string]*vectorQuery{request.VectorField: {Vector: request.Vector}}
+		request.Vector = nil
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	docs, err := s.search(segments[0], request)
+	if err != nil {
+		return nil, err
+	}
+	groupCount, groupTopk := request.GroupCount, request.GroupTopk
+	if groupCount <= 0 {
+		groupCount = defaultGroupCount
+	}
+	if groupTopk <= 0 {
+		groupTopk = defaultGroupTopk
+	}
+	groups := make([]*group, 0)
+	groupsMap := make(map[string]*group)
+	for _, d := range docs {
+		value, ok := d.Fields[request.GroupByField]
+		if !ok || value == nil {
+			continue
+		}
+		groupId := gconv.String(value)
+		g, ok := groupsMap[groupId]
+		if !ok {
+			if len(groups) >= groupCount {
+				continue
+			}
+			g = &group{GroupId: groupId}
+			groupsMap[groupId] = g
+			groups = append(groups, g)
+		}
+		if len(g.Docs) < groupTopk {
+			g.Docs = append(g.Docs, outputDocument(d, request))
+		}
+	}
+	return docsResponse(groups, &usage{ReadUnits: maxInt(len(groups), 1)}), nil
+}
+
+// search returns scored copies of the matching documents, best first.
+func (s *Server) search(collectionName string, request *documentsQueryRequest) ([]*document, *apiError) {
+	p, err := s.partition(collectionName, request.Partition)
+	if err != nil {
+		return nil, err
+	}
+	c := s.collections[collectionName]
+	var expr filter.Expr
+	if request.Filter != "" {
+		var parseErr error
+		if expr, parseErr = filter.Parse(request.Filter); parseErr != nil {
+			return nil, newApiError(http.StatusBadRequest, codeInvalidFilter, parseErr.Error())
+		}
+	}
+	targets, ok := c.queryTargets(p, request)
+	if !ok {
+		return []*document{}, nil
+	}
+	for _, target := range targets {
+		if len(target.vector) > 0 && len(target.vector) != target.schema.Dimension {
+			return nil, newApiError(http.StatusBadRequest, codeMismatchedDimension, "mismatched dimension")
+		}
+	}
+	candidates := make([]*document, 0, len(p.Docs))
+	for _, id := range sortedKeys(p.Docs) {
+		d := p.Docs[id]
+		if expr != nil && !match(expr, d.Fields) {
+			continue
+		}
+		candidates = append(candidates, d)
+	}
+	return rank(c, candidates, targets, request), nil
+}
+
+type queryTarget struct {
+	name   string
+	schema *vectorSchema
+	vector []float32
+	sparse map[string]float32
+}
+
+func (c *collection) queryTargets(p *partition, request *documentsQueryRequest) ([]*queryTarget, bool) {
+	vector, vectors, sparse := request.Vector, make(map[string][]float32), request.SparseVector
+	for name, query := range request.Vectors {
+		if query != nil {
+			vectors[name] = query.Vector
+		}
+	}
+	if request.Id != "" {
+		d, ok := p.Docs[request.Id]
+		if !ok {
+			return nil, false
+		}
+		vector, sparse = d.Vector, d.SparseVector
+		if len(vectors) == 0 {
+			for name, v := range d.Vectors {
+				vectors[name] = v
+			}
+		} else {
+			for name := range vectors {
+				vectors[name] = d.Vectors[name]
+			}
+		}
+	}
+	var targets []*queryTarget
+	if !c.Named && len(vector) > 0 {
+		targets = append(targets, &queryTarget{schema: c.VectorsSchema[defaultVectorName], vector: vector, sparse: sparse})
+	}
+	if c.Named {
+		for _, name := range sortedKeys(vectors) {
+			if schema, ok := c.VectorsSchema[name]; ok && len(vectors[name]) > 0 {
+				targets = append(targets, &queryTarget{name: name, schema: schema, vector: vectors[name]})
+			}
+		}
+	}
+	return targets, true
+}
+
+func rank(c *collection, candidates []*document, targets []*queryTarget, request *documentsQueryRequest) []*document {
+	if len(targets) == 0 {
+		return scored(candidates, make([]float64, len(candidates)))
+	}
+	if len(targets) == 1 {
+		target := targets[0]
+		var docs []*document
+		var scores []float64
+		for _, d := range candidates {
+			if v := target.docVector(d); len(v) > 0 {
+				docs = append(docs, d)
+				scores = append(scores, score(target.schema.Metric, target.vector, v, target.sparse, d.SparseVector))
+			}
+		}
+		return sortByScore(docs, scores, target.schema.Metric != metricDotproduct)
+	}
+	weights := map[string]float64{}
+	rankConstant := defaultRankConstant
+	weighted := false
+	if request.Rerank != nil {
+		params := &struct {
+			RankConstant int    `json:"rank_constant"`
+			Weights      string `json:"weights"`
+		}{}
+		_ = json.Unmarshal(request.Rerank.RankerParams, params)
+		if request.Rerank.RankerName == "weighted" {
+			weighted = true
+			_ = json.Unmarshal([]byte(params.Weights), &weights)
+		} else if params.RankConstant > 0 {
+			rankConstant = params.RankConstant
+		}
+	}
+	fused := make(map[string]float64)
+	for _, target := range targets {
+		ranked := rank(c, candidates, []*queryTarget{target}, request)
+		for i, d := range ranked {
+			if weighted {
+				fused[d.Id] += weights[target.name] * normalize(target.schema.Metric, float64(d.Score))
+			} else {
+				fused[d.Id] += 1 / float64(rankConstant+i+1)
+			}
+		}
+	}
+	var docs []*document
+	var scores []float64
+	for _, d := range candidates {
+		if value, ok := fused[d.Id]; ok {
+			docs = append(docs, d)
+			scores = append(scores, value)
+		}
+	}
+	return sortByScore(docs, scores, false)
+}
+
+func (t *queryTarget) docVector(d *document) []float32 {
+	if t.name == "" {
+		return d.Vector
+	}
+	return d.Vectors[t.name]
+}
+
+func scored(docs []*document, scores []float64) []*document {
+	result := make([]*document, 0, len(docs))
+	for i, d := range docs {
+		copied := *d
+		copied.Score = float32(scores[i])
+		result = append(result, &copied)
+	}
+	return result
+}
+
+func sortByScore(docs []*document, scores []float64, ascending bool) []*document {
+	result := scored(docs, scores)
+	sort.SliceStable(result, func(i, j int) bool {
+		if ascending {
+			return result[i].Score < result[j].Score
+		}
+		return result[i].Score > result[j].Score
+	})
+	return result
+}
+
+func score(metric string, query, vector []float32, querySparse, sparse map[string]float32) float64 {
+	var dot, queryNorm, norm, distance float64
+	for i := range query {
+		q, v := float64(query[i]), float64(vector[i])
+		dot += q * v
+		queryNorm += q * q
+		norm += v * v
+		distance += (q - v) * (q - v)
+	}
+	switch metric {
+	case metricEuclidean:
+		return distance
+	case metricDotproduct:
+		for key, q := range querySparse {
+			dot += float64(q) * float64(sparse[key])
+		}
+		return dot
+	default:
+		if queryNorm == 0 || norm == 0 {
+			return 1
+		}
+		return 1 - dot/math.Sqrt(queryNorm*norm)
+	}
+}
+
+func normalize(metric string, score float64) float64 {
+	switch metric {
+	case metricEuclidean:
+		return 1 - 2*math.Atan(score)/math.Pi
+	case metricDotproduct:
+		return 0.5 + math.Atan(score)/math.Pi
+	default:
+		return 1 - score/2
+	}
+}
+
+func outputDocument(d *document, request *documentsQueryRequest) *document {
+	result := &document{Id: d.Id, Score: d.Score}
+	if request.IncludeVector {
+		result.Vector, result.Vectors, result.SparseVector = d.Vector, d.Vectors, d.SparseVector
+	}
+	if request.OutputFields == nil {
+		result.Fields = d.Fields
+		return result
+	}
+	result.Fields = make(map[string]any)
+	for _, name := range request.OutputFields {
+		if value, ok := d.Fields[name]; ok {
+			result.Fields[name] = value
+		}
+	}
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func match(expr filter.Expr, fields map[string]any) bool {
+	switch e := expr.(type) {
+	case *filter.Logical:
+		for _, operand := range e.Exprs {
+			matched := match(operand, fields)
+			if e.Op == filter.OpOr && matched {
+				return true
+			}
+			if e.Op == filter.OpAnd && !matched {
+				return false
+			}
+		}
+		return e.Op == filter.OpAnd
+	case *filter.Null:
+		value, ok := fields[e.Field]
+		return (!ok || value == nil) != e.Not
+	case *filter.Compare:
+		value, ok := fields[e.Field]
+		if !ok || value == nil {
+			return false
+		}
+		return compare(e.Op, value, e.Value)
+	}
+	return false
+}
+
+func compare(op string, value, literal any) bool {
+	switch op {
+	case filter.OpLike:
+		return like(gconv.String(value), gconv.String(literal))
+	case filter.OpNotLike:
+		return !like(gconv.String(value), gconv.String(literal))
+	}
+	var cmp int
+	switch v := value.(type) {
+	case string:
+		l, ok := literal.(string)
+		if !ok {
+			return false
+		}
+		cmp = compareOrdered(v, l)
+	case bool:
+		l, ok := literal.(bool)
+		if !ok {
+			return false
+		}
+		cmp = compareOrdered(strconv.FormatBool(v), strconv.FormatBool(l))
+	default:
+		if _, ok := literal.(string); ok {
+			return false
+		}
+		if _, ok := literal.(bool); ok {
+			return false
+		}
+		cmp = compareOrdered(gconv.Float64(value), gconv.Float64(literal))
+	}
+	switch op {
+	case filter.OpEq:
+		return cmp == 0
+	case filter.OpNe:
+		return cmp != 0
+	case filter.OpGt:
+		return cmp > 0
+	case filter.OpGe:
+		return cmp >= 0
+	case filter.OpLt:
+		return cmp < 0
+	case filter.OpLe:
+		return cmp <= 0
+	}
+	return false
+}
+
+func compareOrdered[T string | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// like matches SQL LIKE patterns, where % matches any sequence and _ matches one character.
+func like(value, pattern string) bool {
+	v, p := []rune(value), []rune(pattern)
+	if len(p) == 0 {
+		return len(v) == 0
+	}
+	switch p[0] {
+	case '%':
+		for i := 0; i <= len(v); i++ {
+			if like(string(v[i:]), string(p[1:])) {
+				return true
+			}
+		}
+		return false
+	case '_':
+		return len(v) > 0 && like(string(v[1:]), string(p[1:]))
+	}
+	return len(v) > 0 && v[0] == p[0] && like(string(v[1:]), string(p[1:]))
+}